@@ -287,6 +287,14 @@ func TestT8n(t *testing.T) {
 			output: t8nOutput{alloc: true, result: true},
 			expOut: "exp.json",
 		},
+		{ // Astria deposit transactions
+			base: "./testdata/33",
+			input: t8nInput{
+				"alloc.json", "txs.json", "env.json", "Shanghai", "",
+			},
+			output: t8nOutput{alloc: true, result: true},
+			expOut: "exp.json",
+		},
 	} {
 		args := []string{"t8n"}
 		args = append(args, tc.output.get()...)
@@ -683,3 +691,35 @@ func cmpJson(a, b []byte) (bool, error) {
 	}
 	return reflect.DeepEqual(j2, j), nil
 }
+
+func TestEvmDepositTests(t *testing.T) {
+	t.Parallel()
+	tt := new(testT8n)
+	tt.TestCmd = cmdtest.NewTestCmd(t, tt)
+
+	// State tests report a result per subtest, in no particular order
+	tt.Run("evm-test", "statetest", "./testdata/34/statetest.json")
+	var results []struct {
+		Name  string `json:"name"`
+		Pass  bool   `json:"pass"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(tt.Output(), &results); err != nil {
+		t.Fatalf("statetest: json parsing failed: %v", err)
+	}
+	tt.WaitExit()
+	if len(results) != 5 {
+		t.Fatalf("statetest: expected 5 results, have %d", len(results))
+	}
+	for _, res := range results {
+		if !res.Pass {
+			t.Errorf("statetest %s: failed: %v", res.Name, res.Error)
+		}
+	}
+
+	tt.Run("evm-test", "blocktest", "./testdata/35/blocktest.json")
+	tt.WaitExit()
+	if have := tt.ExitStatus(); have != 0 {
+		t.Fatalf("blocktest: wrong exit code, have %d, want 0", have)
+	}
+}
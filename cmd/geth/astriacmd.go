@@ -0,0 +1,284 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/grpc/shared"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	astriaCommand = &cli.Command{
+		Name:  "astria",
+		Usage: "A set of commands for Astria rollups",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "validate-genesis",
+				Usage:     "Validate the Astria configuration of a genesis file",
+				ArgsUsage: "<genesisPath>",
+				Action:    validateAstriaGenesis,
+				Description: `
+geth astria validate-genesis <genesisPath>
+This command checks every Astria field of the genesis file at every scheduled height,
+and prints a report of the problems found. It fails if any error is found, warnings
+do not make it fail.
+ `,
+			},
+		},
+	}
+)
+
+// astriaGenesisReport collects the results of the checks of an Astria genesis.
+type astriaGenesisReport struct {
+	lines    []string
+	errors   int
+	warnings int
+}
+
+func (r *astriaGenesisReport) ok(format string, args ...interface{}) {
+	r.lines = append(r.lines, "[OK]    "+fmt.Sprintf(format, args...))
+}
+
+func (r *astriaGenesisReport) warn(format string, args ...interface{}) {
+	r.lines = append(r.lines, "[WARN]  "+fmt.Sprintf(format, args...))
+	r.warnings++
+}
+
+func (r *astriaGenesisReport) fail(format string, args ...interface{}) {
+	r.lines = append(r.lines, "[ERROR] "+fmt.Sprintf(format, args...))
+	r.errors++
+}
+
+func (r *astriaGenesisReport) print(w io.Writer) {
+	for _, line := range r.lines {
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "\n%d errors, %d warnings\n", r.errors, r.warnings)
+}
+
+func validateAstriaGenesis(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return fmt.Errorf("need genesis.json file as the only argument")
+	}
+	file, err := os.Open(ctx.Args().First())
+	if err != nil {
+		return fmt.Errorf("failed to read genesis file: %v", err)
+	}
+	defer file.Close()
+
+	genesis := new(core.Genesis)
+	if err := json.NewDecoder(file).Decode(genesis); err != nil {
+		return fmt.Errorf("invalid genesis file: %v", err)
+	}
+	if genesis.Config == nil {
+		return fmt.Errorf("genesis file has no chain config")
+	}
+	report := checkAstriaGenesis(genesis)
+	report.print(os.Stdout)
+	if report.errors > 0 {
+		return fmt.Errorf("genesis file has %d errors", report.errors)
+	}
+	return nil
+}
+
+// checkAstriaGenesis runs all the checks of the Astria configuration of the genesis,
+// covering the values scheduled at every height rather than the current one only.
+func checkAstriaGenesis(genesis *core.Genesis) *astriaGenesisReport {
+	var (
+		report = new(astriaGenesisReport)
+		config = genesis.Config
+	)
+	checkAstriaChain(report, config)
+	checkAstriaBridges(report, genesis)
+	checkAstriaFeeCollectors(report, config)
+	checkAstriaAuctioneers(report, config)
+	checkAstriaEIP1559Params(report, config)
+	checkAstriaRollupDataDecoders(report, config)
+	return report
+}
+
+func checkAstriaChain(report *astriaGenesisReport, config *params.ChainConfig) {
+	if config.AstriaRollupName == "" {
+		report.fail("rollup name not set")
+	} else {
+		report.ok("rollup name %q", config.AstriaRollupName)
+	}
+	if config.AstriaSequencerInitialHeight == 0 {
+		report.fail("sequencer initial height not set")
+	} else {
+		report.ok("sequencer initial height %d", config.AstriaSequencerInitialHeight)
+	}
+	if config.AstriaCelestiaInitialHeight == 0 {
+		report.fail("celestia initial height not set")
+	} else {
+		report.ok("celestia initial height %d", config.AstriaCelestiaInitialHeight)
+	}
+	if config.AstriaCelestiaHeightVariance == 0 {
+		report.fail("celestia height variance not set")
+	} else {
+		report.ok("celestia height variance %d", config.AstriaCelestiaHeightVariance)
+	}
+	if config.AstriaSequencerAddressPrefix == "" {
+		report.fail("sequencer address prefix not set")
+	} else {
+		report.ok("sequencer address prefix %q", config.AstriaSequencerAddressPrefix)
+	}
+}
+
+func checkAstriaBridges(report *astriaGenesisReport, genesis *core.Genesis) {
+	config := genesis.Config
+	if len(config.AstriaBridgeAddressConfigs) == 0 {
+		report.warn("bridge addresses not set, deposits will be ignored")
+		return
+	}
+	var (
+		nativeBridgeSeen bool
+		bridgeAddresses  = make(map[string]bool)
+	)
+	for _, cfg := range config.AstriaBridgeAddressConfigs {
+		if err := cfg.Validate(config.AstriaSequencerAddressPrefix); err != nil {
+			report.fail("bridge %s: %v", cfg.BridgeAddress, err)
+			continue
+		}
+		failed := false
+		if bridgeAddresses[cfg.BridgeAddress] {
+			report.fail("bridge %s: configured more than once", cfg.BridgeAddress)
+			failed = true
+		}
+		bridgeAddresses[cfg.BridgeAddress] = true
+
+		if cfg.Erc20Asset == nil {
+			if nativeBridgeSeen {
+				report.fail("bridge %s: only one native bridge address is allowed", cfg.BridgeAddress)
+				failed = true
+			}
+			nativeBridgeSeen = true
+		} else {
+			if cfg.SenderAddress == (common.Address{}) {
+				report.fail("bridge %s: sender address must be set for bridged ERC20 assets", cfg.BridgeAddress)
+				failed = true
+			}
+			contract := cfg.Erc20Asset.ContractAddress
+			if account, ok := genesis.Alloc[contract]; !ok || len(account.Code) == 0 {
+				report.fail("bridge %s: ERC20 contract %s has no code in the genesis alloc", cfg.BridgeAddress, contract)
+				failed = true
+			}
+		}
+		if err := shared.ValidateBech32mAddress(cfg.BridgeAddress, config.AstriaSequencerAddressPrefix); err != nil {
+			report.warn("bridge %s: %v", cfg.BridgeAddress, err)
+		}
+		if !failed {
+			report.ok("bridge %s for asset %s from height %d, precision %d", cfg.BridgeAddress, cfg.AssetDenom, cfg.StartHeight, cfg.AssetPrecision)
+		}
+	}
+}
+
+func checkAstriaFeeCollectors(report *astriaGenesisReport, config *params.ChainConfig) {
+	heights := sortedKeys(config.AstriaFeeCollectors)
+	if len(heights) == 0 || heights[0] > 1 {
+		report.warn("no fee collector set from the first block, fees will be burned until one is")
+	}
+	for _, h := range heights {
+		if collector := config.AstriaFeeCollectors[h]; collector == (common.Address{}) {
+			report.warn("fee collector at height %d is the zero address, fees will be burned", h)
+		} else {
+			report.ok("fee collector at height %d is %s", h, collector)
+		}
+	}
+	if err := config.ValidateAstriaFeeSplits(); err != nil {
+		report.fail("fee splits: %v", err)
+	} else {
+		for _, h := range sortedKeys(config.AstriaFeeSplits) {
+			report.ok("fee split at height %d has %d shares", h, len(config.AstriaFeeSplits[h]))
+		}
+	}
+}
+
+func checkAstriaAuctioneers(report *astriaGenesisReport, config *params.ChainConfig) {
+	heights := sortedKeys(config.AstriaAuctioneerAddresses)
+	if len(heights) == 0 {
+		report.warn("auctioneer addresses not set, allocations will be ignored")
+		return
+	}
+	for _, h := range heights {
+		address := config.AstriaAuctioneerAddresses[h]
+		if address == "" {
+			report.ok("auctions disabled at height %d", h)
+			continue
+		}
+		if err := shared.ValidateBech32mAddress(address, config.AstriaSequencerAddressPrefix); err != nil {
+			report.fail("auctioneer address %s at height %d: %v", address, h, err)
+		} else {
+			report.ok("auctioneer address at height %d is %s", h, address)
+		}
+	}
+}
+
+func checkAstriaEIP1559Params(report *astriaGenesisReport, config *params.ChainConfig) {
+	if config.AstriaEIP1559Params == nil {
+		report.ok("eip1559 params not set, defaults apply")
+		return
+	}
+	err := config.AstriaEIP1559Params.Validate()
+	if err == nil {
+		report.ok("eip1559 params scheduled at heights %v", config.AstriaEIP1559Params.Heights())
+		return
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range joined.Unwrap() {
+			report.fail("%v", err)
+		}
+	} else {
+		report.fail("%v", err)
+	}
+}
+
+func checkAstriaRollupDataDecoders(report *astriaGenesisReport, config *params.ChainConfig) {
+	if len(config.AstriaRollupDataDecoders) == 0 {
+		return
+	}
+	if config.AstriaRollupDataEnvelopeHeight == 0 {
+		report.warn("rollup data decoders set without an envelope height, they will never be used")
+	}
+	for _, name := range sortedKeys(config.AstriaRollupDataDecoders) {
+		height := config.AstriaRollupDataDecoders[name]
+		switch name {
+		case shared.ZstdBatchDecoderName, shared.BrotliBatchDecoderName:
+			report.ok("rollup data decoder %s enabled at height %d", name, height)
+		default:
+			report.warn("rollup data decoder %s at height %d is not built in, it must be registered by the node", name, height)
+		}
+	}
+}
+
+func sortedKeys[K uint32 | string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/grpc/shared"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func testAstriaGenesis(t *testing.T) *core.Genesis {
+	bridgeAddress, err := shared.EncodeFromBytes("astria", [20]byte{1})
+	if err != nil {
+		t.Fatalf("failed to encode bridge address: %v", err)
+	}
+	erc20BridgeAddress, err := shared.EncodeFromBytes("astria", [20]byte{2})
+	if err != nil {
+		t.Fatalf("failed to encode bridge address: %v", err)
+	}
+	auctioneerAddress, err := shared.EncodeFromBytes("astria", [20]byte{3})
+	if err != nil {
+		t.Fatalf("failed to encode auctioneer address: %v", err)
+	}
+	erc20 := common.HexToAddress("0x00000000000000000000000000000000000e2c20")
+	config := *params.MergedTestChainConfig
+	config.AstriaRollupName = "astria"
+	config.AstriaSequencerInitialHeight = 2
+	config.AstriaCelestiaInitialHeight = 2
+	config.AstriaCelestiaHeightVariance = 10
+	config.AstriaSequencerAddressPrefix = "astria"
+	config.AstriaBridgeAddressConfigs = []params.AstriaBridgeAddressConfig{
+		{BridgeAddress: bridgeAddress, StartHeight: 1, AssetDenom: "nria", AssetPrecision: 9},
+		{
+			BridgeAddress:  erc20BridgeAddress,
+			StartHeight:    1,
+			AssetDenom:     "usdc",
+			AssetPrecision: 6,
+			SenderAddress:  common.HexToAddress("0x00000000000000000000000000000000000000bb"),
+			Erc20Asset:     &params.AstriaErc20AssetConfig{ContractAddress: erc20, ContractPrecision: 18},
+		},
+	}
+	config.AstriaFeeCollectors = map[uint32]common.Address{1: common.HexToAddress("0x00000000000000000000000000000000000000aa")}
+	config.AstriaAuctioneerAddresses = map[uint32]string{1: auctioneerAddress, 10: ""}
+	config.AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+		1: {MinBaseFee: 10, ElasticityMultiplier: 2, BaseFeeChangeDenominator: 8},
+	})
+	return &core.Genesis{
+		Config: &config,
+		Alloc:  types.GenesisAlloc{erc20: {Code: []byte{0x1}, Balance: new(big.Int)}},
+	}
+}
+
+func TestCheckAstriaGenesis(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(genesis *core.Genesis)
+		errors   []string
+		warnings int
+	}{
+		{
+			name:   "valid",
+			mutate: func(genesis *core.Genesis) {},
+		},
+		{
+			name: "missing chain fields",
+			mutate: func(genesis *core.Genesis) {
+				genesis.Config.AstriaRollupName = ""
+				genesis.Config.AstriaCelestiaHeightVariance = 0
+			},
+			errors: []string{"rollup name not set", "celestia height variance not set"},
+		},
+		{
+			name: "erc20 contract without code",
+			mutate: func(genesis *core.Genesis) {
+				genesis.Alloc = types.GenesisAlloc{}
+			},
+			errors: []string{"has no code in the genesis alloc"},
+		},
+		{
+			name: "invalid bridge precision",
+			mutate: func(genesis *core.Genesis) {
+				genesis.Config.AstriaBridgeAddressConfigs[0].AssetPrecision = 19
+			},
+			errors: []string{"asset precision of native asset must be less than or equal to 18"},
+		},
+		{
+			name: "auctioneer with wrong prefix at a later height",
+			mutate: func(genesis *core.Genesis) {
+				address, _ := shared.EncodeFromBytes("other", [20]byte{4})
+				genesis.Config.AstriaAuctioneerAddresses[20] = address
+			},
+			errors: []string{"auctioneer address " + "other"},
+		},
+		{
+			name: "invalid eip1559 params at a later height",
+			mutate: func(genesis *core.Genesis) {
+				genesis.Config.AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+					1:  {MinBaseFee: 10, ElasticityMultiplier: 2, BaseFeeChangeDenominator: 8},
+					10: {MinBaseFee: 10, ElasticityMultiplier: 2, BaseFeeChangeDenominator: 0},
+					20: {MinBaseFee: 10, MaxBaseFee: 5, ElasticityMultiplier: 2, BaseFeeChangeDenominator: 8},
+				})
+			},
+			errors: []string{"eip1559 params at height 10", "eip1559 params at height 20"},
+		},
+		{
+			name: "fee collector scheduled late",
+			mutate: func(genesis *core.Genesis) {
+				genesis.Config.AstriaFeeCollectors = map[uint32]common.Address{5: {}}
+			},
+			warnings: 2,
+		},
+	}
+	for _, tt := range tests {
+		genesis := testAstriaGenesis(t)
+		tt.mutate(genesis)
+		report := checkAstriaGenesis(genesis)
+
+		output := new(strings.Builder)
+		report.print(output)
+		if report.errors != len(tt.errors) {
+			t.Errorf("%s: expected %d errors, got %d\n%s", tt.name, len(tt.errors), report.errors, output)
+		}
+		if report.warnings != tt.warnings {
+			t.Errorf("%s: expected %d warnings, got %d\n%s", tt.name, tt.warnings, report.warnings, output)
+		}
+		for _, want := range tt.errors {
+			if !strings.Contains(output.String(), "[ERROR] ") || !strings.Contains(output.String(), want) {
+				t.Errorf("%s: expected error containing %q\n%s", tt.name, want, output)
+			}
+		}
+	}
+}
@@ -214,12 +214,20 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 		}
 
 		serviceV1a2 := execution.NewExecutionServiceServerV1(sharedService)
+		if cfg.Node.GRPCVerifySequencerProofs {
+			serviceV1a2.EnableSequencerInclusionProofVerification()
+		}
 
 		auctionServiceV1Alpha1 := optimistic.NewAuctionServiceV1Alpha1(sharedService)
 
 		utils.RegisterGRPCServices(stack, serviceV1a2, auctionServiceV1Alpha1, auctionServiceV1Alpha1, &cfg.Node)
 	}
 
+	// Forward new transactions to a composer if requested.
+	if ctx.IsSet(utils.ComposerEndpointFlag.Name) {
+		utils.RegisterComposerForwarder(stack, eth, ctx.String(utils.ComposerEndpointFlag.Name))
+	}
+
 	// Add the Ethereum Stats daemon if requested.
 	if cfg.Ethstats.URL != "" {
 		utils.RegisterEthStatsService(stack, backend, cfg.Ethstats.URL)
@@ -78,6 +78,7 @@ var (
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolLifetimeFlag,
+		utils.TxPoolAuctioneerRetentionFlag,
 		utils.BlobPoolDataDirFlag,
 		utils.BlobPoolDataCapFlag,
 		utils.BlobPoolPriceBumpFlag,
@@ -192,6 +193,8 @@ var (
 		utils.GRPCEnabledFlag,
 		utils.GRPCHostFlag,
 		utils.GRPCPortFlag,
+		utils.GRPCVerifySequencerProofsFlag,
+		utils.ComposerEndpointFlag,
 	}
 
 	metricsFlags = []cli.Flag{
@@ -249,6 +252,8 @@ func init() {
 		snapshotCommand,
 		// See verkle.go
 		verkleCommand,
+		// See astriacmd.go
+		astriaCommand,
 	}
 	if logTestCommand != nil {
 		app.Commands = append(app.Commands, logTestCommand)
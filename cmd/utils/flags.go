@@ -60,6 +60,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb/remotedb"
 	"github.com/ethereum/go-ethereum/ethstats"
 	"github.com/ethereum/go-ethereum/graphql"
+	"github.com/ethereum/go-ethereum/grpc/composer"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/flags"
 	"github.com/ethereum/go-ethereum/log"
@@ -403,6 +404,12 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Lifetime,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolAuctioneerRetentionFlag = &cli.Uint64Flag{
+		Name:     "txpool.auctioneerretention",
+		Usage:    "Number of optimistic heads unexecuted transactions are retained across in auctioneer mode (0 = clear on every head)",
+		Value:    ethconfig.Defaults.TxPool.AuctioneerRetention,
+		Category: flags.TxPoolCategory,
+	}
 	// Blob transaction pool settings
 	BlobPoolDataDirFlag = &cli.StringFlag{
 		Name:     "blobpool.datadir",
@@ -770,6 +777,18 @@ var (
 		Value:    node.DefaultGRPCPort,
 		Category: flags.APICategory,
 	}
+	GRPCVerifySequencerProofsFlag = &cli.BoolFlag{
+		Name:     "grpc.verifysequencerproofs",
+		Usage:    "Verify executed rollup data against the sequencer block header and rollup transactions proof",
+		Category: flags.APICategory,
+	}
+
+	// composer
+	ComposerEndpointFlag = &cli.StringFlag{
+		Name:     "composer.endpoint",
+		Usage:    "gRPC endpoint of a composer to forward new transactions of the pool to",
+		Category: flags.APICategory,
+	}
 
 	// auctioneer
 	AuctioneerEnabledFlag = &cli.BoolFlag{
@@ -1225,6 +1244,9 @@ func setGRPC(ctx *cli.Context, cfg *node.Config) {
 		if ctx.IsSet(GRPCPortFlag.Name) {
 			cfg.GRPCPort = ctx.Int(GRPCPortFlag.Name)
 		}
+		if ctx.IsSet(GRPCVerifySequencerProofsFlag.Name) {
+			cfg.GRPCVerifySequencerProofs = ctx.Bool(GRPCVerifySequencerProofsFlag.Name)
+		}
 	}
 }
 
@@ -1589,6 +1611,9 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.Duration(TxPoolLifetimeFlag.Name)
 	}
+	if ctx.IsSet(TxPoolAuctioneerRetentionFlag.Name) {
+		cfg.AuctioneerRetention = ctx.Uint64(TxPoolAuctioneerRetentionFlag.Name)
+	}
 }
 
 func setMiner(ctx *cli.Context, cfg *miner.Config) {
@@ -2008,6 +2033,18 @@ func RegisterGRPCServices(stack *node.Node, execServ astriaGrpc.ExecutionService
 	}
 }
 
+// RegisterComposerForwarder adds a service forwarding the new transactions of the pool
+// to the composer at the given endpoint.
+func RegisterComposerForwarder(stack *node.Node, backend *eth.Ethereum, endpoint string) {
+	config := composer.DefaultConfig
+	config.Endpoint = endpoint
+	forwarder, err := composer.NewForwarder(config, backend.BlockChain().Config().AstriaRollupName, backend.TxPool())
+	if err != nil {
+		Fatalf("Failed to register the composer forwarder: %v", err)
+	}
+	stack.RegisterLifecycle(forwarder)
+}
+
 // RegisterFilterAPI adds the eth log filtering RPC API to the node.
 func RegisterFilterAPI(stack *node.Node, backend ethapi.Backend, ethcfg *ethconfig.Config) *filters.FilterSystem {
 	filterSystem := filters.NewFilterSystem(backend, filters.Config{
@@ -32,18 +32,29 @@ import (
 // - gas limit check
 // - basefee check
 func VerifyEIP1559Header(config *params.ChainConfig, parent, header *types.Header) error {
-	// Verify that the gas limit remains within allowed bounds
-	parentGasLimit := parent.GasLimit
-	if !config.IsLondon(parent.Number) {
-		parentGasLimit = parent.GasLimit * config.ElasticityMultiplier(parent.Number.Uint64())
-	}
-	if err := misc.VerifyGaslimit(parentGasLimit, header.GasLimit); err != nil {
-		return err
+	if gasLimit, ok := config.AstriaGasLimit(header.Number.Uint64()); ok {
+		// Verify that the gas limit is the scheduled one
+		if header.GasLimit != gasLimit {
+			return fmt.Errorf("invalid gas limit: have %d, want scheduled %d", header.GasLimit, gasLimit)
+		}
+	} else {
+		// Verify that the gas limit remains within allowed bounds
+		parentGasLimit := parent.GasLimit
+		if !config.IsLondon(parent.Number) {
+			parentGasLimit = parent.GasLimit * config.ElasticityMultiplier(parent.Number.Uint64())
+		}
+		if err := misc.VerifyGaslimit(parentGasLimit, header.GasLimit); err != nil {
+			return err
+		}
 	}
 	// Verify the header is not malformed
 	if header.BaseFee == nil {
 		return errors.New("header is missing baseFee")
 	}
+	// Verify the baseFee does not exceed the scheduled cap
+	if maxBaseFee := config.AstriaMaxBaseFee(header.Number.Uint64()); maxBaseFee != nil && header.BaseFee.Cmp(maxBaseFee) > 0 {
+		return fmt.Errorf("invalid baseFee: have %s, above scheduled maximum %s", header.BaseFee, maxBaseFee)
+	}
 	// Verify the baseFee is correct based on the parent header.
 	expectedBaseFee := CalcBaseFee(config, parent)
 	if header.BaseFee.Cmp(expectedBaseFee) != 0 {
@@ -53,8 +64,17 @@ func VerifyEIP1559Header(config *params.ChainConfig, parent, header *types.Heade
 	return nil
 }
 
-// CalcBaseFee calculates the basefee of the header.
+// CalcBaseFee calculates the basefee of the header, capped at the maximum base fee
+// scheduled for its height.
 func CalcBaseFee(config *params.ChainConfig, parent *types.Header) *big.Int {
+	baseFee := calcBaseFee(config, parent)
+	if maxBaseFee := config.AstriaMaxBaseFee(parent.Number.Uint64() + 1); maxBaseFee != nil {
+		return math.BigMin(baseFee, maxBaseFee)
+	}
+	return baseFee
+}
+
+func calcBaseFee(config *params.ChainConfig, parent *types.Header) *big.Int {
 	// If the current block is the first EIP-1559 block, return the InitialBaseFee.
 	if !config.IsLondon(parent.Number) {
 		return new(big.Int).SetUint64(params.InitialBaseFee)
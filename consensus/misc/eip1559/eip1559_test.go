@@ -129,3 +129,93 @@ func TestCalcBaseFee(t *testing.T) {
 		}
 	}
 }
+
+// TestAstriaScheduledGasLimit tests that the scheduled gas limit replaces the bounds
+// on the gas limit change once it is active.
+func TestAstriaScheduledGasLimit(t *testing.T) {
+	initial := new(big.Int).SetUint64(params.InitialBaseFee)
+	config := config()
+	config.AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+		0:  {ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator},
+		10: {ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator, GasLimit: 50000000},
+	})
+
+	for i, tc := range []struct {
+		pGasLimit uint64
+		pNum      int64
+		gasLimit  uint64
+		ok        bool
+	}{
+		{20000000, 8, 20000000, true},  // Not scheduled yet
+		{20000000, 8, 50000000, false}, // Not scheduled yet, above upper limit
+		{20000000, 9, 50000000, true},  // Scheduled jump
+		{20000000, 9, 20000000, false}, // Not the scheduled limit
+		{50000000, 10, 50000000, true}, // Scheduled
+		{50000000, 10, 50000001, false},
+	} {
+		parent := &types.Header{
+			GasUsed:  tc.pGasLimit / 2,
+			GasLimit: tc.pGasLimit,
+			BaseFee:  initial,
+			Number:   big.NewInt(tc.pNum),
+		}
+		header := &types.Header{
+			GasUsed:  tc.gasLimit / 2,
+			GasLimit: tc.gasLimit,
+			BaseFee:  initial,
+			Number:   big.NewInt(tc.pNum + 1),
+		}
+		err := VerifyEIP1559Header(config, parent, header)
+		if tc.ok && err != nil {
+			t.Errorf("test %d: Expected valid header: %s", i, err)
+		}
+		if !tc.ok && err == nil {
+			t.Errorf("test %d: Expected invalid header", i)
+		}
+	}
+}
+
+// TestAstriaMaxBaseFee tests that the base fee is capped at the scheduled maximum once
+// it is active.
+func TestAstriaMaxBaseFee(t *testing.T) {
+	config := config()
+	config.AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+		0:  {ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator},
+		10: {ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator, MaxBaseFee: 1050000000},
+	})
+
+	tests := []struct {
+		parentBaseFee   int64
+		parentNum       int64
+		parentGasUsed   uint64
+		expectedBaseFee int64
+	}{
+		{params.InitialBaseFee, 8, 20000000, 1125000000}, // usage above target, not capped yet
+		{params.InitialBaseFee, 9, 20000000, 1050000000}, // usage above target, capped
+		{params.InitialBaseFee, 9, 10000000, 1000000000}, // usage at target, below the cap
+		{1100000000, 9, 10000000, 1050000000},            // usage at target, above the cap
+		{1100000000, 9, 9000000, 1050000000},             // usage below target, above the cap
+		{params.InitialBaseFee, 9, 9000000, 987500000},   // usage below target
+	}
+	for i, test := range tests {
+		parent := &types.Header{
+			Number:   big.NewInt(test.parentNum),
+			GasLimit: 20000000,
+			GasUsed:  test.parentGasUsed,
+			BaseFee:  big.NewInt(test.parentBaseFee),
+		}
+		if have, want := CalcBaseFee(config, parent), big.NewInt(test.expectedBaseFee); have.Cmp(want) != 0 {
+			t.Errorf("test %d: have %d  want %d, ", i, have, want)
+		}
+	}
+
+	parent := &types.Header{Number: big.NewInt(9), GasLimit: 20000000, GasUsed: 20000000, BaseFee: big.NewInt(params.InitialBaseFee)}
+	header := &types.Header{Number: big.NewInt(10), GasLimit: 20000000, BaseFee: big.NewInt(1125000000)}
+	if err := VerifyEIP1559Header(config, parent, header); err == nil {
+		t.Errorf("expected header with base fee above the maximum to be invalid")
+	}
+	header.BaseFee = big.NewInt(1050000000)
+	if err := VerifyEIP1559Header(config, parent, header); err != nil {
+		t.Errorf("expected header with capped base fee to be valid: %v", err)
+	}
+}
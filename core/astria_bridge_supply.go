@@ -0,0 +1,210 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// astriaBridgeSupplyRetention is the number of recent blocks whose bridge supply is kept
+// by non-archive nodes, which are the blocks whose state is kept to serve it.
+const astriaBridgeSupplyRetention = state.TriesInMemory
+
+// AstriaBridgeTotals is the amount a bridge minted via deposits and burned via withdrawals,
+// in rollup precision.
+type AstriaBridgeTotals struct {
+	Minted *big.Int `json:"minted"`
+	Burned *big.Int `json:"burned"`
+}
+
+// ErrAstriaBridgeSupplyNotFound is returned if the bridge supply of a block was not
+// accumulated, as the block was processed before the accumulator existed.
+var ErrAstriaBridgeSupplyNotFound = errors.New("bridge supply not accumulated")
+
+// AstriaBridgeSupply accumulates the totals of every bridge up to and including a block,
+// keyed by bridge address. It is updated with every processed block and persisted keyed
+// by the block hash, counting from the block the accumulator started at.
+type AstriaBridgeSupply struct {
+	// Since is the number of the first block counted by the accumulator.
+	Since uint64 `json:"since"`
+	// WithdrawerBalances are the balances of the withdrawers of the native bridges before
+	// the first counted block, so that the amount withdrawn since can be read from state.
+	WithdrawerBalances map[common.Address]*big.Int    `json:"withdrawerBalances,omitempty"`
+	Bridges            map[string]*AstriaBridgeTotals `json:"bridges,omitempty"`
+}
+
+// newAstriaBridgeSupply starts accumulating the bridge supply at the given block, on top
+// of the state of its parent.
+func newAstriaBridgeSupply(config *params.ChainConfig, number uint64, parent *state.StateDB) *AstriaBridgeSupply {
+	supply := &AstriaBridgeSupply{
+		Since:              number,
+		WithdrawerBalances: make(map[common.Address]*big.Int),
+		Bridges:            make(map[string]*AstriaBridgeTotals),
+	}
+	for _, bridge := range config.AstriaBridgeAddressConfigs {
+		if bridge.Erc20Asset == nil && bridge.WithdrawerAddress != nil {
+			supply.WithdrawerBalances[*bridge.WithdrawerAddress] = parent.GetBalance(*bridge.WithdrawerAddress).ToBig()
+		}
+	}
+	return supply
+}
+
+// ReadAstriaBridgeSupply loads the bridge supply accumulated up to the block with the given
+// hash. It returns ErrAstriaBridgeSupplyNotFound if the block was not accumulated.
+func ReadAstriaBridgeSupply(db ethdb.KeyValueReader, hash common.Hash) (*AstriaBridgeSupply, error) {
+	data := rawdb.ReadAstriaBridgeSupply(db, hash)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w for block %s", ErrAstriaBridgeSupplyNotFound, hash)
+	}
+	supply := new(AstriaBridgeSupply)
+	if err := json.Unmarshal(data, supply); err != nil {
+		return nil, fmt.Errorf("failed to decode bridge supply of block %s: %w", hash, err)
+	}
+	if supply.WithdrawerBalances == nil {
+		supply.WithdrawerBalances = make(map[common.Address]*big.Int)
+	}
+	if supply.Bridges == nil {
+		supply.Bridges = make(map[string]*AstriaBridgeTotals)
+	}
+	return supply, nil
+}
+
+// WithdrawnNative returns the amount withdrawn from the native bridge since the accumulator
+// started, as the growth of the balance of its withdrawer in the given state. Withdrawals
+// lock their value in the withdrawer, taking it out of the rollup supply.
+func (s *AstriaBridgeSupply) WithdrawnNative(bridge *params.AstriaBridgeAddressConfig, statedb *state.StateDB) *big.Int {
+	if bridge.WithdrawerAddress == nil {
+		return new(big.Int)
+	}
+	withdrawn := statedb.GetBalance(*bridge.WithdrawerAddress).ToBig()
+	if start, ok := s.WithdrawerBalances[*bridge.WithdrawerAddress]; ok {
+		withdrawn.Sub(withdrawn, start)
+	}
+	return withdrawn
+}
+
+// writeAstriaBridgeSupply persists the bridge supply accumulated up to the block with the
+// given hash.
+func writeAstriaBridgeSupply(db ethdb.KeyValueWriter, hash common.Hash, supply *AstriaBridgeSupply) error {
+	data, err := json.Marshal(supply)
+	if err != nil {
+		return err
+	}
+	rawdb.WriteAstriaBridgeSupply(db, hash, data)
+	return nil
+}
+
+// accumulateAstriaBridgeSupply writes the bridge supply accumulated up to the given block
+// on top of the one of its parent, starting to accumulate it if the parent has none. It
+// also prunes the bridge supply of the blocks which fell out of the retention window.
+func (bc *BlockChain) accumulateAstriaBridgeSupply(db ethdb.KeyValueWriter, block *types.Block, receipts types.Receipts) error {
+	if number := block.NumberU64(); !bc.cacheConfig.TrieDirtyDisabled && number > astriaBridgeSupplyRetention {
+		for _, hash := range rawdb.ReadAllHashes(bc.db, number-astriaBridgeSupplyRetention) {
+			rawdb.DeleteAstriaBridgeSupply(db, hash)
+		}
+	}
+	supply, err := ReadAstriaBridgeSupply(bc.db, block.ParentHash())
+	if errors.Is(err, ErrAstriaBridgeSupplyNotFound) {
+		parent := bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+		if parent == nil {
+			return consensus.ErrUnknownAncestor
+		}
+		parentState, err := bc.StateAt(parent.Root)
+		if err != nil {
+			return err
+		}
+		log.Info("Started accumulating bridge supply", "number", block.NumberU64(), "hash", block.Hash())
+		supply = newAstriaBridgeSupply(bc.chainConfig, block.NumberU64(), parentState)
+	} else if err != nil {
+		return err
+	}
+	return writeAstriaBridgeSupply(db, block.Hash(), supply.Apply(bc.chainConfig, block, receipts))
+}
+
+// totals returns the totals of the given bridge, creating them if needed.
+func (s *AstriaBridgeSupply) totals(bridgeAddress string) *AstriaBridgeTotals {
+	totals, ok := s.Bridges[bridgeAddress]
+	if !ok {
+		totals = &AstriaBridgeTotals{Minted: new(big.Int), Burned: new(big.Int)}
+		s.Bridges[bridgeAddress] = totals
+	}
+	return totals
+}
+
+// Apply returns the supply after the given block, with the given receipts. Native deposits
+// always mint their value, even if their call failed, while ERC20 deposits only mint if
+// their receipt is successful. Withdrawals are the withdrawal events of successful
+// transactions emitted by a bridge contract.
+func (s *AstriaBridgeSupply) Apply(config *params.ChainConfig, block *types.Block, receipts types.Receipts) *AstriaBridgeSupply {
+	next := &AstriaBridgeSupply{
+		Since:              s.Since,
+		WithdrawerBalances: s.WithdrawerBalances,
+		Bridges:            make(map[string]*AstriaBridgeTotals, len(s.Bridges)),
+	}
+	for bridgeAddress, totals := range s.Bridges {
+		next.Bridges[bridgeAddress] = &AstriaBridgeTotals{
+			Minted: new(big.Int).Set(totals.Minted),
+			Burned: new(big.Int).Set(totals.Burned),
+		}
+	}
+	number := block.NumberU64()
+	for i, tx := range block.Transactions() {
+		if i >= len(receipts) {
+			break
+		}
+		receipt := receipts[i]
+		if tx.Type() == types.DepositTxType {
+			bridge := config.AstriaDepositBridge(number, tx.From(), tx.To())
+			if bridge == nil {
+				continue
+			}
+			totals := next.totals(bridge.BridgeAddress)
+			if bridge.Erc20Asset == nil {
+				totals.Minted.Add(totals.Minted, tx.Value())
+			} else if _, amount, ok := astriaMintArgs(tx.Data()); ok && receipt.Status == types.ReceiptStatusSuccessful {
+				totals.Minted.Add(totals.Minted, amount)
+			}
+			continue
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		for _, log := range receipt.Logs {
+			withdrawal := decodeAstriaWithdrawal(log)
+			if withdrawal == nil {
+				continue
+			}
+			if bridge := config.AstriaWithdrawalBridge(number, log.Address); bridge != nil {
+				totals := next.totals(bridge.BridgeAddress)
+				totals.Burned.Add(totals.Burned, withdrawal.Amount)
+			}
+		}
+	}
+	return next
+}
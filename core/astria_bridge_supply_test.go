@@ -0,0 +1,190 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// astriaTestWithdrawerCode emits a `SequencerWithdrawal` event of the call value with the
+// calldata as event data, and keeps the value.
+func astriaTestWithdrawerCode() []byte {
+	code := []byte{
+		byte(vm.CALLDATASIZE), byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.CALLDATACOPY),
+		byte(vm.CALLVALUE), byte(vm.CALLER), byte(vm.PUSH32),
+	}
+	code = append(code, AstriaSequencerWithdrawalTopic.Bytes()...)
+	return append(code, byte(vm.CALLDATASIZE), byte(vm.PUSH1), 0x00, byte(vm.LOG3), byte(vm.STOP))
+}
+
+func TestAstriaBridgeSupply(t *testing.T) {
+	var (
+		engine     = beacon.NewFaker()
+		key, _     = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr       = crypto.PubkeyToAddress(key.PublicKey)
+		sender     = common.HexToAddress("0x000000000000000000000000000000000000bbbb")
+		withdrawer = common.HexToAddress("0x000000000000000000000000000000000000eeee")
+		config     = *params.MergedTestChainConfig
+		gspec      = &Genesis{
+			Config: &config,
+			Alloc: types.GenesisAlloc{
+				addr:       {Balance: big.NewInt(params.Ether)},
+				withdrawer: {Code: astriaTestWithdrawerCode()},
+			},
+		}
+	)
+	config.AstriaBridgeAddressConfigs = []params.AstriaBridgeAddressConfig{{
+		BridgeAddress:     "astria1bridge",
+		SenderAddress:     sender,
+		StartHeight:       1,
+		AssetDenom:        "nria",
+		AssetPrecision:    9,
+		WithdrawerAddress: &withdrawer,
+	}}
+	signer := types.LatestSigner(gspec.Config)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 3, func(i int, b *BlockGen) {
+		switch i {
+		case 0:
+			b.AddTx(types.NewTx(&types.DepositTx{From: sender, Value: big.NewInt(1000), To: &addr}))
+		case 1:
+			tx, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+				ChainID:   gspec.Config.ChainID,
+				To:        &withdrawer,
+				Value:     big.NewInt(300),
+				Gas:       100000,
+				GasFeeCap: newGwei(5),
+				Data:      abiEncodeStrings("astria1destination"),
+			})
+			b.AddTx(tx)
+		}
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+
+	for i, want := range []AstriaBridgeTotals{
+		{Minted: big.NewInt(1000), Burned: big.NewInt(0)},
+		{Minted: big.NewInt(1000), Burned: big.NewInt(300)},
+		{Minted: big.NewInt(1000), Burned: big.NewInt(300)},
+	} {
+		supply, err := ReadAstriaBridgeSupply(chain.db, blocks[i].Hash())
+		if err != nil {
+			t.Fatalf("block %d: failed to read bridge supply: %v", i+1, err)
+		}
+		totals, ok := supply.Bridges["astria1bridge"]
+		if !ok {
+			t.Fatalf("block %d: missing bridge supply", i+1)
+		}
+		if totals.Minted.Cmp(want.Minted) != 0 || totals.Burned.Cmp(want.Burned) != 0 {
+			t.Errorf("block %d: have minted %d burned %d, want minted %d burned %d", i+1, totals.Minted, totals.Burned, want.Minted, want.Burned)
+		}
+		if supply.Since != 1 {
+			t.Errorf("block %d: have accumulator start %d, want 1", i+1, supply.Since)
+		}
+		statedb, err := chain.StateAt(blocks[i].Root())
+		if err != nil {
+			t.Fatalf("block %d: failed to open state: %v", i+1, err)
+		}
+		if withdrawn := supply.WithdrawnNative(&config.AstriaBridgeAddressConfigs[0], statedb); withdrawn.Cmp(want.Burned) != 0 {
+			t.Errorf("block %d: have withdrawn %d from state, want %d", i+1, withdrawn, want.Burned)
+		}
+	}
+	if _, err := ReadAstriaBridgeSupply(chain.db, chain.Genesis().Hash()); !errors.Is(err, ErrAstriaBridgeSupplyNotFound) {
+		t.Errorf("genesis: have error %v, want %v", err, ErrAstriaBridgeSupplyNotFound)
+	}
+}
+
+func TestAstriaBridgeSupplyIndex(t *testing.T) {
+	var (
+		engine = beacon.NewFaker()
+		sender = common.HexToAddress("0x000000000000000000000000000000000000bbbb")
+		config = *params.MergedTestChainConfig
+		gspec  = &Genesis{Config: &config}
+	)
+	config.AstriaBridgeAddressConfigs = []params.AstriaBridgeAddressConfig{{
+		BridgeAddress:  "astria1bridge",
+		SenderAddress:  sender,
+		StartHeight:    1,
+		AssetDenom:     "nria",
+		AssetPrecision: 9,
+	}}
+	genDb, blocks, _ := GenerateChainWithGenesis(gspec, engine, int(astriaBridgeSupplyRetention)+3, func(i int, b *BlockGen) {})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// failing to accumulate the bridge supply does not fail the block, and accumulation
+	// restarts with the next block
+	if n, err := chain.InsertChain(blocks[:1]); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	rawdb.WriteAstriaBridgeSupply(chain.db, blocks[0].Hash(), []byte("corrupt"))
+	if n, err := chain.InsertChain(blocks[1:3]); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	if _, err := ReadAstriaBridgeSupply(chain.db, blocks[1].Hash()); !errors.Is(err, ErrAstriaBridgeSupplyNotFound) {
+		t.Errorf("block 2: have error %v, want %v", err, ErrAstriaBridgeSupplyNotFound)
+	}
+	if supply, err := ReadAstriaBridgeSupply(chain.db, blocks[2].Hash()); err != nil || supply.Since != 3 {
+		t.Errorf("block 3: have supply %v and error %v, want accumulator start 3", supply, err)
+	}
+
+	// the bridge supply of the blocks dropped by a reorg is deleted
+	fork, _ := GenerateChain(gspec.Config, blocks[2], engine, genDb, 2, func(i int, b *BlockGen) {
+		b.SetCoinbase(sender)
+	})
+	if n, err := chain.InsertChain(blocks[3:4]); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	if n, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("fork block %d: failed to insert into chain: %v", n, err)
+	}
+	if _, err := ReadAstriaBridgeSupply(chain.db, blocks[3].Hash()); !errors.Is(err, ErrAstriaBridgeSupplyNotFound) {
+		t.Errorf("dropped block 4: have error %v, want %v", err, ErrAstriaBridgeSupplyNotFound)
+	}
+	if _, err := ReadAstriaBridgeSupply(chain.db, fork[1].Hash()); err != nil {
+		t.Errorf("fork block 5: failed to read bridge supply: %v", err)
+	}
+
+	// the bridge supply of the blocks out of the retention window is pruned
+	if n, err := chain.InsertChain(blocks[3:]); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	if _, err := ReadAstriaBridgeSupply(chain.db, blocks[2].Hash()); !errors.Is(err, ErrAstriaBridgeSupplyNotFound) {
+		t.Errorf("block 3: have error %v, want %v", err, ErrAstriaBridgeSupplyNotFound)
+	}
+	if _, err := ReadAstriaBridgeSupply(chain.db, blocks[4].Hash()); err != nil {
+		t.Errorf("block 5: failed to read bridge supply: %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// astriaMintSelector is the selector of `mint(address,uint256)`, which ERC20 deposits call.
+	astriaMintSelector = crypto.Keccak256([]byte("mint(address,uint256)"))[:4]
+
+	// AstriaSequencerWithdrawalTopic is the topic of the event emitted by a withdrawal
+	// to the sequencer.
+	AstriaSequencerWithdrawalTopic = crypto.Keccak256Hash([]byte("SequencerWithdrawal(address,uint256,string)"))
+	// AstriaIcs20WithdrawalTopic is the topic of the event emitted by a withdrawal to an
+	// IBC chain via the sequencer.
+	AstriaIcs20WithdrawalTopic = crypto.Keccak256Hash([]byte("Ics20Withdrawal(address,uint256,string,string)"))
+)
+
+// astriaMintArgs returns the recipient and amount of a `mint(address,uint256)` call.
+func astriaMintArgs(data []byte) (common.Address, *big.Int, bool) {
+	if len(data) < 4+2*32 || !bytes.Equal(data[:4], astriaMintSelector) {
+		return common.Address{}, nil, false
+	}
+	return common.BytesToAddress(data[4 : 4+32]), new(big.Int).SetBytes(data[4+32 : 4+2*32]), true
+}
+
+// astriaDepositRecipient returns the account credited by a successful call of a deposit
+// transaction with the given calldata: the recipient of an ERC20 mint, or the called
+// contract of a deposit call.
+func (st *StateTransition) astriaDepositRecipient(data []byte) common.Address {
+	if st.msg.Value.Sign() == 0 {
+		if recipient, _, ok := astriaMintArgs(data); ok {
+			return recipient
+		}
+	}
+	return *st.msg.To
+}
+
+// traceAstriaDeposit reports the executed deposit transaction to the tracer, if it
+// listens to deposits.
+func (st *StateTransition) traceAstriaDeposit(recipient common.Address) {
+	tracer := st.evm.Config.Tracer
+	if tracer == nil || tracer.OnAstriaDeposit == nil {
+		return
+	}
+	deposit := &tracing.AstriaDeposit{
+		Recipient:              recipient,
+		Amount:                 new(big.Int).Set(st.msg.Value),
+		SourceTransactionId:    st.msg.DepositSourceTransactionId,
+		SourceTransactionIndex: st.msg.DepositSourceTransactionIndex,
+	}
+	if bridge := st.evm.ChainConfig().AstriaDepositBridge(st.evm.Context.BlockNumber.Uint64(), st.msg.From, st.msg.To); bridge != nil {
+		deposit.BridgeAddress = bridge.BridgeAddress
+		deposit.AssetDenom = bridge.AssetDenom
+	}
+	if st.msg.Value.Sign() == 0 {
+		if _, amount, ok := astriaMintArgs(st.msg.Data); ok {
+			deposit.Asset = *st.msg.To
+			deposit.Amount = amount
+		}
+	}
+	tracer.OnAstriaDeposit(deposit)
+}
+
+// traceAstriaWithdrawals reports the withdrawal events in the logs of a successful
+// transaction to the tracer, if it listens to withdrawals.
+func traceAstriaWithdrawals(tracer *tracing.Hooks, config *params.ChainConfig, receipt *types.Receipt) {
+	if tracer == nil || tracer.OnAstriaWithdrawal == nil || receipt.Status != types.ReceiptStatusSuccessful {
+		return
+	}
+	for _, log := range receipt.Logs {
+		withdrawal := decodeAstriaWithdrawal(log)
+		if withdrawal == nil {
+			continue
+		}
+		if bridge := config.AstriaWithdrawalBridge(log.BlockNumber, log.Address); bridge != nil {
+			withdrawal.BridgeAddress = bridge.BridgeAddress
+			withdrawal.AssetDenom = bridge.AssetDenom
+		}
+		tracer.OnAstriaWithdrawal(withdrawal)
+	}
+}
+
+// decodeAstriaWithdrawal decodes a `SequencerWithdrawal` or `Ics20Withdrawal` event, or
+// returns nil if the log is not a well formed withdrawal event.
+func decodeAstriaWithdrawal(log *types.Log) *tracing.AstriaWithdrawal {
+	if len(log.Topics) != 3 {
+		return nil
+	}
+	withdrawal := &tracing.AstriaWithdrawal{
+		Contract: log.Address,
+		Sender:   common.BytesToAddress(log.Topics[1].Bytes()),
+		Amount:   log.Topics[2].Big(),
+	}
+	var ok bool
+	switch log.Topics[0] {
+	case AstriaSequencerWithdrawalTopic:
+		withdrawal.DestinationChainAddress, ok = abiString(log.Data, 0)
+	case AstriaIcs20WithdrawalTopic:
+		withdrawal.Ibc = true
+		if withdrawal.DestinationChainAddress, ok = abiString(log.Data, 0); ok {
+			withdrawal.Memo, ok = abiString(log.Data, 1)
+		}
+	}
+	if !ok {
+		return nil
+	}
+	return withdrawal
+}
+
+// abiString decodes the ABI encoded string which is the given argument of the data.
+func abiString(data []byte, arg int) (string, bool) {
+	word := func(offset uint64) (uint64, bool) {
+		if offset > uint64(len(data)) || uint64(len(data))-offset < 32 {
+			return 0, false
+		}
+		value := new(big.Int).SetBytes(data[offset : offset+32])
+		if !value.IsUint64() {
+			return 0, false
+		}
+		return value.Uint64(), true
+	}
+	offset, ok := word(uint64(arg) * 32)
+	if !ok {
+		return "", false
+	}
+	size, ok := word(offset)
+	if !ok || size > uint64(len(data))-offset-32 {
+		return "", false
+	}
+	return string(data[offset+32 : offset+32+size]), true
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	primitivev1 "buf.build/gen/go/astria/primitives/protocolbuffers/go/astria/primitive/v1"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestTraceAstriaDeposit(t *testing.T) {
+	tests := []struct {
+		name          string
+		tx            *types.DepositTx
+		wantRecipient common.Address
+		wantAsset     common.Address
+		wantAmount    int64
+	}{
+		{
+			name:          "native",
+			tx:            &types.DepositTx{From: depositTestBridge, Value: big.NewInt(1000), To: &depositTestUser},
+			wantRecipient: depositTestUser,
+			wantAmount:    1000,
+		},
+		{
+			name: "erc20",
+			tx: &types.DepositTx{
+				From: depositTestBridge, Value: new(big.Int), Gas: params.DefaultAstriaErc20MintGasLimit, To: &depositTestToken,
+				Data: depositTestMintCalldata(depositTestUser, 100),
+			},
+			wantRecipient: depositTestUser,
+			wantAsset:     depositTestToken,
+			wantAmount:    100,
+		},
+		{
+			name: "erc20 minted to fallback",
+			tx: &types.DepositTx{
+				From: depositTestBridge, Value: new(big.Int), Gas: params.DefaultAstriaErc20MintGasLimit, To: &depositTestToken,
+				Data:         depositTestMintCalldata(depositTestBlocked, 100),
+				FallbackData: depositTestMintCalldata(depositTestRefund, 100),
+			},
+			wantRecipient: depositTestRefund,
+			wantAsset:     depositTestToken,
+			wantAmount:    100,
+		},
+		{
+			name: "erc20 lost",
+			tx: &types.DepositTx{
+				From: depositTestBridge, Value: new(big.Int), Gas: params.DefaultAstriaErc20MintGasLimit, To: &depositTestToken,
+				Data: depositTestMintCalldata(depositTestBlocked, 100),
+			},
+			wantAsset:  depositTestToken,
+			wantAmount: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.tx.SourceTransactionId = primitivev1.TransactionId{Inner: "0x1234"}
+			tt.tx.SourceTransactionIndex = 3
+			var deposits []*tracing.AstriaDeposit
+			tracer := &tracing.Hooks{
+				OnAstriaDeposit: func(deposit *tracing.AstriaDeposit) { deposits = append(deposits, deposit) },
+			}
+			statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			statedb.SetCode(depositTestToken, depositTestTokenCode())
+			applyDepositTestTxWithTracer(t, statedb, types.NewTx(tt.tx), tracer)
+
+			want := &tracing.AstriaDeposit{
+				Asset:                  tt.wantAsset,
+				Recipient:              tt.wantRecipient,
+				Amount:                 big.NewInt(tt.wantAmount),
+				SourceTransactionId:    "0x1234",
+				SourceTransactionIndex: 3,
+			}
+			if len(deposits) != 1 {
+				t.Fatalf("expected 1 traced deposit, got %d", len(deposits))
+			}
+			if !reflect.DeepEqual(deposits[0], want) {
+				t.Errorf("unexpected traced deposit: have %+v, want %+v", deposits[0], want)
+			}
+		})
+	}
+}
+
+// abiEncodeStrings returns the ABI encoding of the given string arguments.
+func abiEncodeStrings(args ...string) []byte {
+	var head, tail []byte
+	for _, arg := range args {
+		head = append(head, common.LeftPadBytes(big.NewInt(int64(32*len(args)+len(tail))).Bytes(), 32)...)
+		tail = append(tail, common.LeftPadBytes(big.NewInt(int64(len(arg))).Bytes(), 32)...)
+		tail = append(tail, common.RightPadBytes([]byte(arg), (len(arg)+31)/32*32)...)
+	}
+	return append(head, tail...)
+}
+
+func TestTraceAstriaWithdrawals(t *testing.T) {
+	var (
+		sender = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		amount = common.BigToHash(big.NewInt(1000))
+		config = &params.ChainConfig{
+			AstriaBridgeAddressConfigs: []params.AstriaBridgeAddressConfig{{
+				BridgeAddress: "astria1bridge",
+				AssetDenom:    "usdc",
+				StartHeight:   1,
+				Erc20Asset:    &params.AstriaErc20AssetConfig{ContractAddress: depositTestToken},
+			}},
+		}
+		receipt = &types.Receipt{
+			Status: types.ReceiptStatusSuccessful,
+			Logs: []*types.Log{
+				{
+					Address:     depositTestToken,
+					Topics:      []common.Hash{AstriaSequencerWithdrawalTopic, common.BytesToHash(sender.Bytes()), amount},
+					Data:        abiEncodeStrings("astria1destination"),
+					BlockNumber: 1,
+				},
+				// not a withdrawal
+				{
+					Address:     depositTestToken,
+					Topics:      []common.Hash{{0x01}, common.BytesToHash(sender.Bytes()), amount},
+					BlockNumber: 1,
+				},
+				// truncated data
+				{
+					Address:     depositTestToken,
+					Topics:      []common.Hash{AstriaIcs20WithdrawalTopic, common.BytesToHash(sender.Bytes()), amount},
+					Data:        abiEncodeStrings("cosmos1destination")[:64],
+					BlockNumber: 1,
+				},
+				{
+					Address:     depositTestUser,
+					Topics:      []common.Hash{AstriaIcs20WithdrawalTopic, common.BytesToHash(sender.Bytes()), amount},
+					Data:        abiEncodeStrings("cosmos1destination", "memo"),
+					BlockNumber: 1,
+				},
+			},
+		}
+	)
+	var withdrawals []*tracing.AstriaWithdrawal
+	tracer := &tracing.Hooks{
+		OnAstriaWithdrawal: func(withdrawal *tracing.AstriaWithdrawal) { withdrawals = append(withdrawals, withdrawal) },
+	}
+	traceAstriaWithdrawals(tracer, config, receipt)
+
+	want := []*tracing.AstriaWithdrawal{
+		{
+			BridgeAddress:           "astria1bridge",
+			AssetDenom:              "usdc",
+			Contract:                depositTestToken,
+			Sender:                  sender,
+			Amount:                  big.NewInt(1000),
+			DestinationChainAddress: "astria1destination",
+		},
+		{
+			Contract:                depositTestUser,
+			Sender:                  sender,
+			Amount:                  big.NewInt(1000),
+			DestinationChainAddress: "cosmos1destination",
+			Memo:                    "memo",
+			Ibc:                     true,
+		},
+	}
+	if !reflect.DeepEqual(withdrawals, want) {
+		t.Errorf("unexpected traced withdrawals: have %+v, want %+v", withdrawals, want)
+	}
+
+	// withdrawals of failed transactions are reverted
+	withdrawals = nil
+	receipt.Status = types.ReceiptStatusFailed
+	traceAstriaWithdrawals(tracer, config, receipt)
+	if len(withdrawals) != 0 {
+		t.Errorf("expected no traced withdrawals of a failed transaction, got %d", len(withdrawals))
+	}
+}
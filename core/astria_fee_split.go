@@ -0,0 +1,82 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// astriaCollectedFees returns the fees paid to the fee recipient of the block by its
+// transactions, which is the priority fee and, if the fee recipient is set after London
+// and the base fee is not redirected to a vault, the base fee.
+func astriaCollectedFees(config *params.ChainConfig, header *types.Header, txs types.Transactions, receipts types.Receipts) *uint256.Int {
+	collectBaseFee := config.IsLondon(header.Number) && header.Coinbase != (common.Address{}) && config.AstriaBaseFeeVault(header.Number.Uint64()) == nil
+	total := new(big.Int)
+	for i, tx := range txs {
+		if tx.Type() == types.DepositTxType {
+			continue
+		}
+		fee := tx.EffectiveGasTipValue(header.BaseFee)
+		if collectBaseFee {
+			fee.Add(fee, header.BaseFee)
+		}
+		total.Add(total, fee.Mul(fee, new(big.Int).SetUint64(receipts[i].GasUsed)))
+	}
+	return uint256.MustFromBig(total)
+}
+
+// ApplyAstriaFeeSplit distributes the fees collected by the fee recipient of the block
+// among the fee split active at its height. The last share receives the remainder of
+// the integer division, and shares without a recipient are burned. Blocks without a fee
+// recipient are not split, as their fees are burned.
+func ApplyAstriaFeeSplit(config *params.ChainConfig, header *types.Header, statedb *state.StateDB, txs types.Transactions, receipts types.Receipts) {
+	split := config.AstriaFeeSplitAt(header.Number.Uint64())
+	if len(split) == 0 || header.Coinbase == (common.Address{}) {
+		return
+	}
+	fees := astriaCollectedFees(config, header, txs, receipts)
+	// the fee recipient may have spent some of the fees within the block
+	if balance := statedb.GetBalance(header.Coinbase); balance.Lt(fees) {
+		log.Warn("Fee recipient spent collected fees, splitting its balance", "number", header.Number, "coinbase", header.Coinbase, "fees", fees, "balance", balance)
+		fees = balance.Clone()
+	}
+	if fees.IsZero() {
+		return
+	}
+	statedb.SubBalance(header.Coinbase, fees, tracing.BalanceDecreaseAstriaFeeSplit)
+
+	remaining := fees.Clone()
+	for i, share := range split {
+		amount := remaining
+		if i < len(split)-1 {
+			amount = new(uint256.Int).Mul(fees, uint256.NewInt(share.Bps))
+			amount.Div(amount, uint256.NewInt(params.AstriaFeeSplitTotalBps))
+			remaining = new(uint256.Int).Sub(remaining, amount)
+		}
+		if share.Recipient != nil {
+			statedb.AddBalance(*share.Recipient, amount, tracing.BalanceIncreaseAstriaFeeSplit)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestAstriaFeeSplit(t *testing.T) {
+	var (
+		engine    = beacon.NewFaker()
+		key, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr      = crypto.PubkeyToAddress(key.PublicKey)
+		collector = common.HexToAddress("0x000000000000000000000000000000000000c011")
+		treasury  = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+		rebate    = common.HexToAddress("0x000000000000000000000000000000000000bbbb")
+		config    = *params.MergedTestChainConfig
+		gspec     = &Genesis{
+			Config: &config,
+			Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+	)
+	// fees are split from the second block on, burning the last share
+	config.AstriaFeeSplits = map[uint32][]params.AstriaFeeShare{
+		2: {
+			{Recipient: &treasury, Bps: 7000},
+			{Recipient: &rebate, Bps: 2000},
+			{Bps: 1000},
+		},
+	}
+	if err := config.ValidateAstriaFeeSplits(); err != nil {
+		t.Fatalf("invalid fee split: %v", err)
+	}
+	signer := types.LatestSigner(gspec.Config)
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 2, func(i int, b *BlockGen) {
+		b.SetCoinbase(collector)
+		tx, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			Nonce:     uint64(i),
+			To:        &common.Address{},
+			Gas:       params.TxGas,
+			GasFeeCap: newGwei(5),
+			GasTipCap: big.NewInt(3),
+		})
+		b.AddTx(tx)
+	})
+
+	var increases, decreases int
+	hooks := &tracing.Hooks{
+		OnBalanceChange: func(addr common.Address, prev, new *big.Int, reason tracing.BalanceChangeReason) {
+			switch reason {
+			case tracing.BalanceIncreaseAstriaFeeSplit:
+				increases++
+			case tracing.BalanceDecreaseAstriaFeeSplit:
+				decreases++
+			}
+		},
+	}
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{Tracer: hooks}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+
+	blockFees := func(block *types.Block) *uint256.Int {
+		tx := block.Transactions()[0]
+		price := new(big.Int).Add(block.BaseFee(), tx.EffectiveGasTipValue(block.BaseFee()))
+		return uint256.MustFromBig(price.Mul(price, new(big.Int).SetUint64(block.GasUsed())))
+	}
+	firstFees, secondFees := blockFees(blocks[0]), blockFees(blocks[1])
+
+	state, _ := chain.State()
+	treasuryShare := new(uint256.Int).Div(new(uint256.Int).Mul(secondFees, uint256.NewInt(7000)), uint256.NewInt(10000))
+	rebateShare := new(uint256.Int).Div(new(uint256.Int).Mul(secondFees, uint256.NewInt(2000)), uint256.NewInt(10000))
+
+	// the collector keeps the fees of the first block, and none of the second
+	if balance := state.GetBalance(collector); !balance.Eq(firstFees) {
+		t.Errorf("collector balance incorrect: expected %d, got %d", firstFees, balance)
+	}
+	if balance := state.GetBalance(treasury); !balance.Eq(treasuryShare) {
+		t.Errorf("treasury balance incorrect: expected %d, got %d", treasuryShare, balance)
+	}
+	if balance := state.GetBalance(rebate); !balance.Eq(rebateShare) {
+		t.Errorf("rebate balance incorrect: expected %d, got %d", rebateShare, balance)
+	}
+	if increases != 2 || decreases != 1 {
+		t.Errorf("unexpected fee split balance changes: %d increases, %d decreases", increases, decreases)
+	}
+}
+
+func TestAstriaFeeSplitZeroCoinbase(t *testing.T) {
+	var (
+		engine   = beacon.NewFaker()
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+		treasury = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+		config   = *params.MergedTestChainConfig
+		gspec    = &Genesis{
+			Config: &config,
+			Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+	)
+	config.AstriaFeeSplits = map[uint32][]params.AstriaFeeShare{
+		1: {{Recipient: &treasury, Bps: params.AstriaFeeSplitTotalBps}},
+	}
+	signer := types.LatestSigner(gspec.Config)
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 1, func(i int, b *BlockGen) {
+		b.SetCoinbase(common.Address{})
+		tx, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			Nonce:     uint64(i),
+			To:        &treasury,
+			Gas:       params.TxGas,
+			GasFeeCap: newGwei(5),
+			GasTipCap: big.NewInt(3),
+		})
+		b.AddTx(tx)
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+
+	// the fees of a block without a fee recipient are not split
+	state, _ := chain.State()
+	tip := uint256.NewInt(3 * params.TxGas)
+	if balance := state.GetBalance(common.Address{}); !balance.Eq(tip) {
+		t.Errorf("zero address balance incorrect: expected %d, got %d", tip, balance)
+	}
+	if balance := state.GetBalance(treasury); !balance.IsZero() {
+		t.Errorf("treasury balance incorrect: expected 0, got %d", balance)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// AstriaGasUsage is the split of the gas of a block between deposit transactions and
+// user transactions.
+type AstriaGasUsage struct {
+	DepositGasLimit  uint64 // gas reserved for deposits
+	DepositGasUsed   uint64 // gas used by deposits, which may exceed the reservation
+	UserGasLimit     uint64 // gas available to user transactions if deposits fit their reservation
+	UserGasUsed      uint64 // gas used by user transactions
+	UserGasRemaining uint64 // gas left for user transactions
+}
+
+// NewAstriaGasUsage returns the gas usage of the block with the given header, transactions
+// and receipts. Deposits only take gas from user transactions once they exceed the gas
+// reserved for them.
+func NewAstriaGasUsage(config *params.ChainConfig, header *types.Header, txs types.Transactions, receipts types.Receipts) AstriaGasUsage {
+	usage := AstriaGasUsage{
+		DepositGasLimit: config.AstriaDepositGasLimit(header.Number.Uint64()),
+	}
+	for i, receipt := range receipts {
+		if i < len(txs) && txs[i].Type() == types.DepositTxType {
+			usage.DepositGasUsed += receipt.GasUsed
+		} else {
+			usage.UserGasUsed += receipt.GasUsed
+		}
+	}
+	if header.GasLimit > usage.DepositGasLimit {
+		usage.UserGasLimit = header.GasLimit - usage.DepositGasLimit
+	}
+	if used := max(usage.DepositGasUsed, usage.DepositGasLimit) + usage.UserGasUsed; used < header.GasLimit {
+		usage.UserGasRemaining = header.GasLimit - used
+	}
+	return usage
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestAstriaGasUsage(t *testing.T) {
+	config := &params.ChainConfig{
+		AstriaEIP1559Params: params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+			1: {ElasticityMultiplier: 2, BaseFeeChangeDenominator: 8, GasLimit: 100000, DepositGasLimit: 50000},
+		}),
+	}
+	var (
+		deposit = types.NewTx(&types.DepositTx{Value: new(big.Int)})
+		user    = types.NewTransaction(0, common.Address{}, new(big.Int), params.TxGas, new(big.Int), nil)
+		header  = &types.Header{Number: big.NewInt(1), GasLimit: 150000}
+	)
+	tests := []struct {
+		txs      types.Transactions
+		gasUsed  []uint64
+		expected AstriaGasUsage
+	}{
+		// deposits within their reservation leave the user gas limit untouched
+		{
+			txs:      types.Transactions{deposit, user},
+			gasUsed:  []uint64{30000, 21000},
+			expected: AstriaGasUsage{DepositGasLimit: 50000, DepositGasUsed: 30000, UserGasLimit: 100000, UserGasUsed: 21000, UserGasRemaining: 79000},
+		},
+		// deposits beyond their reservation take gas from user transactions
+		{
+			txs:      types.Transactions{deposit, deposit, user},
+			gasUsed:  []uint64{40000, 40000, 21000},
+			expected: AstriaGasUsage{DepositGasLimit: 50000, DepositGasUsed: 80000, UserGasLimit: 100000, UserGasUsed: 21000, UserGasRemaining: 49000},
+		},
+	}
+	for i, tt := range tests {
+		receipts := make(types.Receipts, len(tt.gasUsed))
+		for j, gasUsed := range tt.gasUsed {
+			receipts[j] = &types.Receipt{GasUsed: gasUsed}
+		}
+		if usage := NewAstriaGasUsage(config, header, tt.txs, receipts); usage != tt.expected {
+			t.Errorf("test %d: have %+v, want %+v", i, usage, tt.expected)
+		}
+	}
+}
+
+// Tests that blocks in which user transactions use the gas reserved for deposits are
+// rejected.
+func TestAstriaUserGasLimitValidation(t *testing.T) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		config = *params.TestChainConfig
+	)
+	config.AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+		1: {
+			ElasticityMultiplier:     params.DefaultElasticityMultiplier,
+			BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator,
+			GasLimit:                 2 * params.TxGas,
+			DepositGasLimit:          params.TxGas,
+		},
+	})
+	gspec := &Genesis{
+		Config: &config,
+		Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+	}
+	signer := types.LatestSigner(&config)
+	for _, txCount := range []int{2, 3} {
+		_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 1, func(i int, b *BlockGen) {
+			for nonce := 0; nonce < txCount; nonce++ {
+				b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+					Nonce:    uint64(nonce),
+					To:       &common.Address{},
+					Gas:      params.TxGas,
+					GasPrice: b.BaseFee(),
+				}))
+			}
+		})
+		chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create blockchain: %v", err)
+		}
+		_, err = chain.InsertChain(blocks)
+		if txCount == 2 && err != nil {
+			t.Errorf("block within the user gas limit rejected: %v", err)
+		}
+		if txCount == 3 && err == nil {
+			t.Error("block using the gas reserved for deposits accepted")
+		}
+		chain.Stop()
+	}
+}
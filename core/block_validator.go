@@ -126,6 +126,11 @@ func (v *BlockValidator) ValidateState(block *types.Block, statedb *state.StateD
 	if block.GasUsed() != usedGas {
 		return fmt.Errorf("invalid gas used (remote: %d local: %d)", block.GasUsed(), usedGas)
 	}
+	// User transactions may not use the gas reserved for deposits. Without a deposit
+	// gas limit scheduled, the user gas limit is the gas limit of the block.
+	if usage := NewAstriaGasUsage(v.config, header, block.Transactions(), receipts); usage.UserGasUsed > usage.UserGasLimit {
+		return fmt.Errorf("invalid user gas used (used: %d limit: %d)", usage.UserGasUsed, usage.UserGasLimit)
+	}
 	// Validate the received block's bloom with the one derived from the generated receipts.
 	// For valid blocks this should always validate to true.
 	rbloom := types.CreateBloom(receipts)
@@ -1484,8 +1484,8 @@ func (bc *BlockChain) writeKnownBlock(block *types.Block) error {
 }
 
 // writeBlockWithState writes block, metadata and corresponding state data to the
-// database.
-func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.Receipt, statedb *state.StateDB) error {
+// database, along with the encoded deposit rate limiter state after the block if set.
+func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.Receipt, statedb *state.StateDB, depositLimits []byte) error {
 	// Calculate the total difficulty of the block
 	ptd := bc.GetTd(block.ParentHash(), block.NumberU64()-1)
 	if ptd == nil {
@@ -1503,6 +1503,16 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	rawdb.WriteBlock(blockBatch, block)
 	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
 	rawdb.WritePreimages(blockBatch, statedb.Preimages())
+	if depositLimits != nil {
+		rawdb.WriteAstriaDepositLimits(blockBatch, block.Hash(), depositLimits)
+	}
+	if len(bc.chainConfig.AstriaBridgeAddressConfigs) > 0 {
+		// The bridge supply is only indexed by the node, so failing to accumulate it does
+		// not fail the block. Accumulation restarts with the next block.
+		if err := bc.accumulateAstriaBridgeSupply(blockBatch, block, receipts); err != nil {
+			log.Warn("Failed to accumulate bridge supply", "number", block.NumberU64(), "hash", block.Hash(), "err", err)
+		}
+	}
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
 	}
@@ -1574,7 +1584,7 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 // writeBlockAndSetHead is the internal implementation of WriteBlockAndSetHead.
 // This function expects the chain mutex to be held.
 func (bc *BlockChain) writeBlockAndSetHead(block *types.Block, receipts []*types.Receipt, logs []*types.Log, state *state.StateDB, emitHeadEvent bool) (status WriteStatus, err error) {
-	if err := bc.writeBlockWithState(block, receipts, state); err != nil {
+	if err := bc.writeBlockWithState(block, receipts, state, nil); err != nil {
 		return NonStatTy, err
 	}
 	currentBlock := bc.CurrentBlock()
@@ -1648,7 +1658,7 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 		return 0, errChainStopped
 	}
 	defer bc.chainmu.Unlock()
-	return bc.insertChain(chain, true)
+	return bc.insertChain(chain, true, nil)
 }
 
 // insertChain is the internal implementation of InsertChain, which assumes that
@@ -1659,7 +1669,10 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 // racey behaviour. If a sidechain import is in progress, and the historic state
 // is imported, but then new canon-head is added before the actual sidechain
 // completes, then the historic state could be pruned again
-func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error) {
+//
+// If depositLimits is set, it is the encoded deposit rate limiter state after the
+// last block of the chain, which is written along with the block.
+func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, depositLimits []byte) (int, error) {
 	// If the chain is terminating, don't even bother starting up.
 	if bc.insertStopped() {
 		return 0, nil
@@ -1865,7 +1878,11 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 		}
 
 		// The traced section of block import.
-		res, err := bc.processBlock(block, statedb, start, setHead)
+		var blockDepositLimits []byte
+		if it.index == len(chain)-1 {
+			blockDepositLimits = depositLimits
+		}
+		res, err := bc.processBlock(block, statedb, start, setHead, blockDepositLimits)
 		followupInterrupt.Store(true)
 		if err != nil {
 			return it.index, err
@@ -1928,7 +1945,7 @@ type blockProcessingResult struct {
 
 // processBlock executes and validates the given block. If there was no error
 // it writes the block and associated state to database.
-func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, start time.Time, setHead bool) (_ *blockProcessingResult, blockEndErr error) {
+func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, start time.Time, setHead bool, depositLimits []byte) (_ *blockProcessingResult, blockEndErr error) {
 	if bc.logger != nil && bc.logger.OnBlockStart != nil {
 		td := bc.GetTd(block.ParentHash(), block.NumberU64()-1)
 		bc.logger.OnBlockStart(tracing.BlockEvent{
@@ -1983,7 +2000,7 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 	)
 	if !setHead {
 		// Don't set the head, only insert the block
-		err = bc.writeBlockWithState(block, receipts, statedb)
+		err = bc.writeBlockWithState(block, receipts, statedb, depositLimits)
 	} else {
 		status, err = bc.writeBlockAndSetHead(block, receipts, logs, statedb, false)
 	}
@@ -2119,7 +2136,7 @@ func (bc *BlockChain) insertSideChain(block *types.Block, it *insertIterator) (i
 		// memory here.
 		if len(blocks) >= 2048 || memory > 64*1024*1024 {
 			log.Info("Importing heavy sidechain segment", "blocks", len(blocks), "start", blocks[0].NumberU64(), "end", block.NumberU64())
-			if _, err := bc.insertChain(blocks, true); err != nil {
+			if _, err := bc.insertChain(blocks, true, nil); err != nil {
 				return 0, err
 			}
 			blocks, memory = blocks[:0], 0
@@ -2133,7 +2150,7 @@ func (bc *BlockChain) insertSideChain(block *types.Block, it *insertIterator) (i
 	}
 	if len(blocks) > 0 {
 		log.Info("Importing sidechain segment", "start", blocks[0].NumberU64(), "end", blocks[len(blocks)-1].NumberU64())
-		return bc.insertChain(blocks, true)
+		return bc.insertChain(blocks, true, nil)
 	}
 	return 0, nil
 }
@@ -2182,7 +2199,7 @@ func (bc *BlockChain) recoverAncestors(block *types.Block) (common.Hash, error)
 		} else {
 			b = bc.GetBlock(hashes[i], numbers[i])
 		}
-		if _, err := bc.insertChain(types.Blocks{b}, false); err != nil {
+		if _, err := bc.insertChain(types.Blocks{b}, false, nil); err != nil {
 			return b.ParentHash(), err
 		}
 	}
@@ -2344,6 +2361,10 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Block) error {
 		}
 		rawdb.DeleteCanonicalHash(indexesBatch, i)
 	}
+	// Delete the bridge supply accumulated along the dropped blocks
+	for _, block := range oldChain {
+		rawdb.DeleteAstriaBridgeSupply(indexesBatch, block.Hash())
+	}
 	if err := indexesBatch.Write(); err != nil {
 		log.Crit("Failed to delete useless indexes", "err", err)
 	}
@@ -2404,10 +2425,30 @@ func (bc *BlockChain) InsertBlockWithoutSetHead(block *types.Block) error {
 	}
 	defer bc.chainmu.Unlock()
 
-	_, err := bc.insertChain(types.Blocks{block}, false)
+	_, err := bc.insertChain(types.Blocks{block}, false, nil)
 	return err
 }
 
+// InsertAstriaBlockWithoutSetHead is InsertBlockWithoutSetHead, additionally persisting
+// the encoded deposit rate limiter state after the block in the same batch as the block,
+// so that a block is never stored without it.
+func (bc *BlockChain) InsertAstriaBlockWithoutSetHead(block *types.Block, depositLimits []byte) error {
+	if !bc.chainmu.TryLock() {
+		return errChainStopped
+	}
+	defer bc.chainmu.Unlock()
+
+	if _, err := bc.insertChain(types.Blocks{block}, false, depositLimits); err != nil {
+		return err
+	}
+	// A known block is not written again. Its limiter state is derived from the same
+	// inputs, so it is only missing if the block was imported without it.
+	if depositLimits != nil && len(rawdb.ReadAstriaDepositLimits(bc.db, block.Hash())) == 0 {
+		rawdb.WriteAstriaDepositLimits(bc.db, block.Hash(), depositLimits)
+	}
+	return nil
+}
+
 // SetCanonical rewinds the chain to set the new head block as the specified
 // block. It's possible that the state of the new head is missing, and it will
 // be recovered in this function as well.
@@ -4233,3 +4233,37 @@ func TestEIP3651(t *testing.T) {
 		t.Fatalf("sender balance incorrect: expected %d, got %d", expected, actual)
 	}
 }
+
+// Tests that the deposit rate limiter state is persisted along with the block inserted,
+// and that it is only written for that block.
+func TestInsertAstriaBlockWithoutSetHead(t *testing.T) {
+	gspec := &Genesis{Config: params.TestChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, func(i int, gen *BlockGen) {})
+
+	db := rawdb.NewMemoryDatabase()
+	chain, err := NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if err := chain.InsertAstriaBlockWithoutSetHead(blocks[0], []byte("limits 1")); err != nil {
+		t.Fatalf("failed to insert block: %v", err)
+	}
+	if err := chain.InsertBlockWithoutSetHead(blocks[1]); err != nil {
+		t.Fatalf("failed to insert block: %v", err)
+	}
+	if data := rawdb.ReadAstriaDepositLimits(db, blocks[0].Hash()); string(data) != "limits 1" {
+		t.Fatalf("unexpected deposit limits of block 1: %q", data)
+	}
+	if data := rawdb.ReadAstriaDepositLimits(db, blocks[1].Hash()); len(data) != 0 {
+		t.Fatalf("unexpected deposit limits of block 2: %q", data)
+	}
+	// a known block gets the limiter state it lacks
+	if err := chain.InsertAstriaBlockWithoutSetHead(blocks[1], []byte("limits 2")); err != nil {
+		t.Fatalf("failed to insert known block: %v", err)
+	}
+	if data := rawdb.ReadAstriaDepositLimits(db, blocks[1].Hash()); string(data) != "limits 2" {
+		t.Fatalf("unexpected deposit limits of known block 2: %q", data)
+	}
+}
@@ -346,6 +346,7 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 			gen(i, b)
 		}
 
+		ApplyAstriaFeeSplit(config, b.header, statedb, b.txs, b.receipts)
 		body := types.Body{Transactions: b.txs, Uncles: b.uncles, Withdrawals: b.withdrawals}
 		block, err := b.engine.FinalizeAndAssemble(cm, b.header, statedb, &body, b.receipts)
 		if err != nil {
@@ -543,6 +544,9 @@ func (cm *chainMaker) makeHeader(parent *types.Block, state *state.StateDB, engi
 			parentGasLimit := parent.GasLimit() * cm.config.ElasticityMultiplier(parent.Number().Uint64())
 			header.GasLimit = CalcGasLimit(parentGasLimit, parentGasLimit)
 		}
+		if gasLimit, ok := cm.config.AstriaGasLimit(header.Number.Uint64()); ok {
+			header.GasLimit = gasLimit
+		}
 	}
 	if cm.config.IsCancun(header.Number, header.Time) {
 		var (
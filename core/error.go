@@ -95,6 +95,10 @@ var (
 	// base fee of the block.
 	ErrFeeCapTooLow = errors.New("max fee per gas less than block base fee")
 
+	// ErrTipBelowMinimum is returned if the effective tip of the transaction is less
+	// than the minimum priority fee scheduled for the block.
+	ErrTipBelowMinimum = errors.New("effective priority fee per gas less than block minimum")
+
 	// ErrSenderNoEOA is returned if the sender of a transaction is a contract.
 	ErrSenderNoEOA = errors.New("sender not an eoa")
 
@@ -28,6 +28,8 @@ type NewTxsEvent struct{ Txs []*types.Transaction }
 type NewMempoolCleared struct {
 	// the new head to which the mempool state was reset to before clearing the mempool
 	NewHead *types.Header
+	// the pending transactions retained across the new head, which can be bid for again
+	Retained []*types.Transaction
 }
 
 // NewMinedBlockEvent is posted when a block has been imported.
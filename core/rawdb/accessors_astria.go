@@ -0,0 +1,61 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ReadAstriaDepositLimits retrieves the encoded deposit rate limiter state after
+// the block with the given hash.
+func ReadAstriaDepositLimits(db ethdb.KeyValueReader, hash common.Hash) []byte {
+	data, _ := db.Get(astriaDepositLimitsKey(hash))
+	return data
+}
+
+// WriteAstriaDepositLimits stores the encoded deposit rate limiter state after the
+// block with the given hash.
+func WriteAstriaDepositLimits(db ethdb.KeyValueWriter, hash common.Hash, data []byte) {
+	if err := db.Put(astriaDepositLimitsKey(hash), data); err != nil {
+		log.Crit("Failed to store astria deposit limits", "err", err)
+	}
+}
+
+// ReadAstriaBridgeSupply retrieves the encoded bridge supply accumulated up to the block
+// with the given hash.
+func ReadAstriaBridgeSupply(db ethdb.KeyValueReader, hash common.Hash) []byte {
+	data, _ := db.Get(astriaBridgeSupplyKey(hash))
+	return data
+}
+
+// WriteAstriaBridgeSupply stores the encoded bridge supply accumulated up to the block
+// with the given hash.
+func WriteAstriaBridgeSupply(db ethdb.KeyValueWriter, hash common.Hash, data []byte) {
+	if err := db.Put(astriaBridgeSupplyKey(hash), data); err != nil {
+		log.Crit("Failed to store astria bridge supply", "err", err)
+	}
+}
+
+// DeleteAstriaBridgeSupply removes the bridge supply accumulated up to the block with the
+// given hash.
+func DeleteAstriaBridgeSupply(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(astriaBridgeSupplyKey(hash)); err != nil {
+		log.Crit("Failed to delete astria bridge supply", "err", err)
+	}
+}
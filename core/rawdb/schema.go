@@ -137,6 +137,9 @@ var (
 
 	CliqueSnapshotPrefix = []byte("clique-")
 
+	astriaDepositLimitsPrefix = []byte("astria-deposit-limits-") // astriaDepositLimitsPrefix + hash -> deposit rate limiter state after the block
+	astriaBridgeSupplyPrefix  = []byte("astria-bridge-supply-")  // astriaBridgeSupplyPrefix + hash -> bridge supply accumulated up to the block
+
 	BestUpdateKey         = []byte("update-")    // bigEndian64(syncPeriod) -> RLP(types.LightClientUpdate)  (nextCommittee only referenced by root hash)
 	FixedCommitteeRootKey = []byte("fixedRoot-") // bigEndian64(syncPeriod) -> committee root hash
 	SyncCommitteeKey      = []byte("committee-") // bigEndian64(syncPeriod) -> serialized committee
@@ -200,6 +203,16 @@ func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
 }
 
+// astriaDepositLimitsKey = astriaDepositLimitsPrefix + hash
+func astriaDepositLimitsKey(hash common.Hash) []byte {
+	return append(astriaDepositLimitsPrefix, hash.Bytes()...)
+}
+
+// astriaBridgeSupplyKey = astriaBridgeSupplyPrefix + hash
+func astriaBridgeSupplyKey(hash common.Hash) []byte {
+	return append(astriaBridgeSupplyPrefix, hash.Bytes()...)
+}
+
 // accountSnapshotKey = SnapshotAccountPrefix + hash
 func accountSnapshotKey(hash common.Hash) []byte {
 	return append(SnapshotAccountPrefix, hash.Bytes()...)
@@ -148,6 +148,16 @@ type Message struct {
 	BlobGasFeeCap *big.Int
 	BlobHashes    []common.Hash
 	IsDepositTx   bool
+	// DepositFallbackData is executed against `To` with a fresh gas budget if the
+	// call of a deposit transaction fails.
+	DepositFallbackData []byte
+	// DepositRefundRecipient receives the value of a deposit transaction whose
+	// call fails.
+	DepositRefundRecipient common.Address
+	// DepositSourceTransactionId and DepositSourceTransactionIndex identify the
+	// sequencer action of a deposit transaction.
+	DepositSourceTransactionId    string
+	DepositSourceTransactionIndex uint64
 
 	// When SkipAccountChecks is true, the message nonce is not checked against the
 	// account nonce in state. It also disables checking that the sender is an EOA.
@@ -180,6 +190,10 @@ func TransactionToMessage(tx *types.Transaction, s types.Signer, baseFee *big.In
 	}
 	if isDepositTx {
 		msg.From = tx.From()
+		msg.DepositFallbackData = tx.DepositFallbackData()
+		msg.DepositRefundRecipient = tx.DepositRefundRecipient()
+		msg.DepositSourceTransactionId = tx.DepositSourceTransactionId()
+		msg.DepositSourceTransactionIndex = tx.DepositSourceTransactionIndex()
 		return msg, nil
 	}
 
@@ -343,6 +357,15 @@ func (st *StateTransition) preCheck() error {
 				return fmt.Errorf("%w: address %v, maxFeePerGas: %s, baseFee: %s", ErrFeeCapTooLow,
 					msg.From.Hex(), msg.GasFeeCap, st.evm.Context.BaseFee)
 			}
+			// Make sure the effective tip is not below the scheduled minimum, unless the
+			// baseFee was explicitly disabled (eth_call)
+			if minTip := st.evm.ChainConfig().AstriaMinPriorityFee(st.evm.Context.BlockNumber.Uint64()); minTip.Sign() > 0 && !st.evm.Config.NoBaseFee {
+				tip := cmath.BigMin(msg.GasTipCap, new(big.Int).Sub(msg.GasFeeCap, st.evm.Context.BaseFee))
+				if tip.Cmp(minTip) < 0 {
+					return fmt.Errorf("%w: address %v, effective tip: %s, minimum: %s", ErrTipBelowMinimum,
+						msg.From.Hex(), tip, minTip)
+				}
+			}
 		}
 	}
 	// Check the blob version validity
@@ -395,6 +418,7 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	if st.msg.IsDepositTx && len(st.msg.Data) == 0 {
 		log.Debug("deposit tx minting funds", "to", *st.msg.To, "value", st.msg.Value)
 		st.state.AddBalance(*st.msg.To, uint256.MustFromBig(st.msg.Value), tracing.BalanceIncreaseAstriaDepositTx)
+		st.traceAstriaDeposit(*st.msg.To)
 		return &ExecutionResult{
 			UsedGas:    0,
 			Err:        nil,
@@ -405,7 +429,14 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	if st.msg.IsDepositTx {
 		st.initialGas = st.msg.GasLimit
 		st.gasRemaining = st.msg.GasLimit
-		log.Debug("deposit tx minting erc20", "to", *st.msg.To, "value", st.msg.Value)
+		// a deposit calling a contract with value first mints the value to the bridge
+		// sender, which then forwards it with the call.
+		if st.msg.Value.Sign() > 0 {
+			log.Debug("deposit tx minting funds to call contract", "from", st.msg.From, "to", *st.msg.To, "value", st.msg.Value)
+			st.state.AddBalance(st.msg.From, uint256.MustFromBig(st.msg.Value), tracing.BalanceIncreaseAstriaDepositTx)
+		} else {
+			log.Debug("deposit tx minting erc20", "to", *st.msg.To, "value", st.msg.Value)
+		}
 	}
 
 	// First check this message satisfies all consensus rules before
@@ -477,6 +508,27 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	// if this is a deposit tx, don't refund gas and also don't pay to the coinbase,
 	// as no gas was used.
 	if st.msg.IsDepositTx {
+		// if the mint failed and the bridge has a fallback, execute it with a fresh gas
+		// budget so that the deposit is not lost.
+		// the recipient credited with the deposit, as reported to tracers
+		recipient := st.astriaDepositRecipient(st.msg.Data)
+		if vmerr != nil && len(st.msg.DepositFallbackData) > 0 {
+			log.Warn("deposit tx call failed, executing fallback", "to", *st.msg.To, "from", st.msg.From, "err", vmerr)
+			st.initialGas = st.gasUsed() + st.msg.GasLimit
+			st.gasRemaining = st.msg.GasLimit
+			ret, st.gasRemaining, vmerr = st.evm.Call(sender, st.to(), st.msg.DepositFallbackData, st.gasRemaining, value)
+			recipient = st.astriaDepositRecipient(st.msg.DepositFallbackData)
+		}
+		// the failed call left the minted value with the bridge sender, hand it to the refund recipient
+		if vmerr != nil && !value.IsZero() && st.msg.DepositRefundRecipient != (common.Address{}) {
+			log.Warn("deposit tx call failed, refunding value", "to", *st.msg.To, "refundRecipient", st.msg.DepositRefundRecipient, "value", value, "err", vmerr)
+			st.evm.Context.Transfer(st.state, st.msg.From, st.msg.DepositRefundRecipient, value)
+			recipient = st.msg.DepositRefundRecipient
+		} else if vmerr != nil {
+			log.Warn("deposit tx call failed, deposit is lost", "to", *st.msg.To, "from", st.msg.From, "err", vmerr)
+			recipient = common.Address{}
+		}
+		st.traceAstriaDeposit(recipient)
 		log.Debug("deposit tx executed", "to", *st.msg.To, "value", st.msg.Value, "from", st.msg.From, "gasUsed", st.gasUsed(), "err", vmerr)
 		return &ExecutionResult{
 			UsedGas:    st.gasUsed(),
@@ -508,10 +560,14 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 		st.state.AddBalance(st.evm.Context.Coinbase, uint256.MustFromBig(fee), tracing.BalanceIncreaseRewardTransactionFee)
 
 		// collect base fee instead of burn
-		if rules.IsLondon && st.evm.Context.Coinbase.Cmp(common.Address{}) != 0 {
+		if rules.IsLondon {
 			baseFee := new(big.Int).SetUint64(st.gasUsed())
 			baseFee.Mul(baseFee, st.evm.Context.BaseFee)
-			st.state.AddBalance(st.evm.Context.Coinbase, uint256.MustFromBig(baseFee), tracing.BalanceIncreaseRewardTransactionFee)
+			if vault := st.evm.ChainConfig().AstriaBaseFeeVault(st.evm.Context.BlockNumber.Uint64()); vault != nil {
+				st.state.AddBalance(*vault, uint256.MustFromBig(baseFee), tracing.BalanceIncreaseAstriaBaseFeeVault)
+			} else if st.evm.Context.Coinbase.Cmp(common.Address{}) != 0 {
+				st.state.AddBalance(st.evm.Context.Coinbase, uint256.MustFromBig(baseFee), tracing.BalanceIncreaseRewardTransactionFee)
+			}
 		}
 	}
 
@@ -0,0 +1,111 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestAstriaBaseFeeVault(t *testing.T) {
+	var (
+		engine    = beacon.NewFaker()
+		key, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr      = crypto.PubkeyToAddress(key.PublicKey)
+		collector = common.HexToAddress("0x000000000000000000000000000000000000c011")
+		vault     = common.HexToAddress("0x000000000000000000000000000000000000fee5")
+		config    = *params.MergedTestChainConfig
+		gspec     = &Genesis{
+			Config: &config,
+			Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+	)
+	// the base fee is redirected to the vault from the second block on, and burned again
+	// from the third block on, as there is no fee recipient
+	config.AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+		1: {ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator},
+		2: {ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator, BaseFeeVault: &vault},
+		3: {ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator},
+	})
+	signer := types.LatestSigner(gspec.Config)
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 3, func(i int, b *BlockGen) {
+		if i < 2 {
+			b.SetCoinbase(collector)
+		}
+		tx, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			Nonce:     uint64(i),
+			To:        &common.Address{},
+			Gas:       params.TxGas,
+			GasFeeCap: newGwei(5),
+			GasTipCap: big.NewInt(3),
+		})
+		b.AddTx(tx)
+	})
+
+	vaultCredits := 0
+	hooks := &tracing.Hooks{
+		OnBalanceChange: func(addr common.Address, prev, new *big.Int, reason tracing.BalanceChangeReason) {
+			if reason == tracing.BalanceIncreaseAstriaBaseFeeVault {
+				if addr != vault {
+					t.Errorf("base fee credited to %v instead of the vault", addr)
+				}
+				vaultCredits++
+			}
+		},
+	}
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, engine, vm.Config{Tracer: hooks}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+
+	baseFee := func(block *types.Block) *uint256.Int {
+		return uint256.MustFromBig(new(big.Int).Mul(block.BaseFee(), new(big.Int).SetUint64(block.GasUsed())))
+	}
+	tip := func(block *types.Block) *uint256.Int {
+		fee := block.Transactions()[0].EffectiveGasTipValue(block.BaseFee())
+		return uint256.MustFromBig(fee.Mul(fee, new(big.Int).SetUint64(block.GasUsed())))
+	}
+
+	state, _ := chain.State()
+	expectedCollector := new(uint256.Int).Add(tip(blocks[0]), baseFee(blocks[0]))
+	expectedCollector.Add(expectedCollector, tip(blocks[1]))
+	if balance := state.GetBalance(collector); !balance.Eq(expectedCollector) {
+		t.Errorf("collector balance incorrect: expected %d, got %d", expectedCollector, balance)
+	}
+	if balance := state.GetBalance(vault); !balance.Eq(baseFee(blocks[1])) {
+		t.Errorf("vault balance incorrect: expected %d, got %d", baseFee(blocks[1]), balance)
+	}
+	if vaultCredits != 1 {
+		t.Errorf("expected 1 base fee vault credit, got %d", vaultCredits)
+	}
+}
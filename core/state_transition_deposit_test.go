@@ -0,0 +1,243 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	depositTestToken   = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+	depositTestBridge  = common.HexToAddress("0x000000000000000000000000000000000000bbbb")
+	depositTestBlocked = common.HexToAddress("0x000000000000000000000000000000000000dead")
+	depositTestRefund  = common.HexToAddress("0x000000000000000000000000000000000000cccc")
+	depositTestUser    = common.HexToAddress("0x000000000000000000000000000000000000dddd")
+)
+
+// depositTestTokenCode is a minimal token whose `mint(address,uint256)` stores the amount
+// in the storage slot of the recipient, and reverts if the recipient is depositTestBlocked.
+func depositTestTokenCode() []byte {
+	code := []byte{byte(vm.PUSH1), 0x04, byte(vm.CALLDATALOAD), byte(vm.DUP1), byte(vm.PUSH20)}
+	code = append(code, depositTestBlocked.Bytes()...)
+	code = append(code,
+		byte(vm.EQ), byte(vm.PUSH1), 0x23, byte(vm.JUMPI),
+		byte(vm.PUSH1), 0x24, byte(vm.CALLDATALOAD), byte(vm.SWAP1), byte(vm.SSTORE), byte(vm.STOP),
+		byte(vm.JUMPDEST), byte(vm.PUSH1), 0x00, byte(vm.DUP1), byte(vm.REVERT),
+	)
+	return code
+}
+
+func depositTestMintCalldata(recipient common.Address, amount int64) []byte {
+	data := common.Hex2Bytes("40c10f19") // mint(address,uint256)
+	data = append(data, common.LeftPadBytes(recipient.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(big.NewInt(amount).Bytes(), 32)...)
+	return data
+}
+
+func applyDepositTestTx(t *testing.T, tx *types.Transaction) (*state.StateDB, *ExecutionResult) {
+	t.Helper()
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.SetCode(depositTestToken, depositTestTokenCode())
+	return statedb, applyDepositTestTxWithState(t, statedb, tx)
+}
+
+func applyDepositTestTxWithState(t *testing.T, statedb *state.StateDB, tx *types.Transaction) *ExecutionResult {
+	t.Helper()
+	return applyDepositTestTxWithTracer(t, statedb, tx, nil)
+}
+
+func applyDepositTestTxWithTracer(t *testing.T, statedb *state.StateDB, tx *types.Transaction, tracer *tracing.Hooks) *ExecutionResult {
+	t.Helper()
+	config := params.MergedTestChainConfig
+	blockContext := vm.BlockContext{
+		CanTransfer: CanTransfer,
+		Transfer:    Transfer,
+		BlockNumber: big.NewInt(1),
+		BaseFee:     big.NewInt(params.InitialBaseFee),
+		GasLimit:    30_000_000,
+		Random:      &common.Hash{},
+	}
+	msg, err := TransactionToMessage(tx, types.LatestSigner(config), blockContext.BaseFee)
+	if err != nil {
+		t.Fatalf("failed to convert deposit tx to message: %v", err)
+	}
+	evm := vm.NewEVM(blockContext, NewEVMTxContext(msg), statedb, config, vm.Config{Tracer: tracer})
+	result, err := ApplyMessage(evm, msg, new(GasPool).AddGas(math.MaxUint64))
+	if err != nil {
+		t.Fatalf("failed to apply deposit tx: %v", err)
+	}
+	return result
+}
+
+func TestErc20DepositMintFallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		recipient    common.Address
+		fallback     []byte
+		wantFailed   bool
+		wantMintedTo common.Address
+	}{
+		{
+			name:         "successful mint",
+			recipient:    depositTestUser,
+			fallback:     depositTestMintCalldata(depositTestRefund, 100),
+			wantMintedTo: depositTestUser,
+		},
+		{
+			name:         "failed mint without fallback",
+			recipient:    depositTestBlocked,
+			wantFailed:   true,
+			wantMintedTo: common.Address{},
+		},
+		{
+			name:         "failed mint with fallback",
+			recipient:    depositTestBlocked,
+			fallback:     depositTestMintCalldata(depositTestRefund, 100),
+			wantMintedTo: depositTestRefund,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := types.NewTx(&types.DepositTx{
+				From:         depositTestBridge,
+				Value:        new(big.Int),
+				Gas:          params.DefaultAstriaErc20MintGasLimit,
+				To:           &depositTestToken,
+				Data:         depositTestMintCalldata(tt.recipient, 100),
+				FallbackData: tt.fallback,
+			})
+			statedb, result := applyDepositTestTx(t, tx)
+			if result.Failed() != tt.wantFailed {
+				t.Fatalf("unexpected result, want failed %v, got err %v", tt.wantFailed, result.Err)
+			}
+			for _, account := range []common.Address{depositTestUser, depositTestBlocked, depositTestRefund} {
+				minted := statedb.GetState(depositTestToken, common.BytesToHash(account.Bytes())).Big()
+				want := int64(0)
+				if account == tt.wantMintedTo {
+					want = 100
+				}
+				if minted.Int64() != want {
+					t.Errorf("minted to %s: want %d, got %d", account, want, minted)
+				}
+			}
+		})
+	}
+}
+
+func TestDepositTxFallbackDataEncoding(t *testing.T) {
+	withoutFallback := types.NewTx(&types.DepositTx{
+		From:  depositTestBridge,
+		Value: new(big.Int),
+		Gas:   params.DefaultAstriaErc20MintGasLimit,
+		To:    &depositTestToken,
+		Data:  depositTestMintCalldata(depositTestUser, 100),
+	})
+	withFallback := types.NewTx(&types.DepositTx{
+		From:         depositTestBridge,
+		Value:        new(big.Int),
+		Gas:          params.DefaultAstriaErc20MintGasLimit,
+		To:           &depositTestToken,
+		Data:         depositTestMintCalldata(depositTestUser, 100),
+		FallbackData: depositTestMintCalldata(depositTestRefund, 100),
+	})
+
+	for _, tx := range []*types.Transaction{withoutFallback, withFallback} {
+		enc, err := tx.MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to encode deposit tx: %v", err)
+		}
+		decoded := new(types.Transaction)
+		if err := decoded.UnmarshalBinary(enc); err != nil {
+			t.Fatalf("failed to decode deposit tx: %v", err)
+		}
+		if decoded.Hash() != tx.Hash() {
+			t.Errorf("hash mismatch after roundtrip: want %s, got %s", tx.Hash(), decoded.Hash())
+		}
+		if !bytes.Equal(decoded.DepositFallbackData(), tx.DepositFallbackData()) {
+			t.Errorf("fallback data mismatch after roundtrip")
+		}
+	}
+}
+
+func TestNativeDepositCall(t *testing.T) {
+	acceptingTarget := common.HexToAddress("0x000000000000000000000000000000000000eeee")
+	revertingTarget := common.HexToAddress("0x000000000000000000000000000000000000ffff")
+
+	tests := []struct {
+		name        string
+		target      common.Address
+		wantFailed  bool
+		wantBalance map[common.Address]int64
+	}{
+		{
+			name:   "successful call",
+			target: acceptingTarget,
+			wantBalance: map[common.Address]int64{
+				acceptingTarget:   100,
+				depositTestUser:   0,
+				depositTestBridge: 0,
+			},
+		},
+		{
+			name:       "failed call refunds recipient",
+			target:     revertingTarget,
+			wantFailed: true,
+			wantBalance: map[common.Address]int64{
+				revertingTarget:   0,
+				depositTestUser:   100,
+				depositTestBridge: 0,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := tt.target
+			tx := types.NewTx(&types.DepositTx{
+				From:            depositTestBridge,
+				Value:           big.NewInt(100),
+				Gas:             100000,
+				To:              &target,
+				Data:            []byte{0x01},
+				RefundRecipient: depositTestUser,
+			})
+
+			statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			statedb.SetCode(acceptingTarget, []byte{byte(vm.STOP)})
+			statedb.SetCode(revertingTarget, []byte{byte(vm.PUSH1), 0x00, byte(vm.DUP1), byte(vm.REVERT)})
+			result := applyDepositTestTxWithState(t, statedb, tx)
+			if result.Failed() != tt.wantFailed {
+				t.Fatalf("unexpected result, want failed %v, got err %v", tt.wantFailed, result.Err)
+			}
+			for account, want := range tt.wantBalance {
+				if got := statedb.GetBalance(account); got.Uint64() != uint64(want) {
+					t.Errorf("balance of %s: want %d, got %d", account, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestAstriaMinPriorityFee(t *testing.T) {
+	var (
+		engine = beacon.NewFaker()
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		config = *params.MergedTestChainConfig
+		gspec  = &Genesis{
+			Config: &config,
+			Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+	)
+	// the minimum priority fee applies from the second block on
+	config.AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+		1: {ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator},
+		2: {ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator, MinPriorityFee: params.GWei},
+	})
+	signer := types.LatestSigner(gspec.Config)
+
+	var errs []error
+	GenerateChainWithGenesis(gspec, engine, 2, func(i int, b *BlockGen) {
+		tx, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			Nonce:     uint64(i),
+			To:        &common.Address{},
+			Gas:       params.TxGas,
+			GasFeeCap: newGwei(5),
+			GasTipCap: big.NewInt(params.GWei - 1),
+		})
+		gasUsed := uint64(0)
+		_, err := ApplyTransaction(gspec.Config, nil, &b.header.Coinbase, new(GasPool).AddGas(b.header.GasLimit), b.statedb, b.header, tx, &gasUsed, vm.Config{})
+		errs = append(errs, err)
+	})
+	if errs[0] != nil {
+		t.Errorf("expected transaction to be valid before activation, got %v", errs[0])
+	}
+	if !errors.Is(errs[1], ErrTipBelowMinimum) {
+		t.Errorf("expected %v after activation, got %v", ErrTipBelowMinimum, errs[1])
+	}
+}
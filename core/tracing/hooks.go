@@ -67,6 +67,37 @@ type BlockEvent struct {
 	Safe      *types.Header
 }
 
+// AstriaDeposit describes a deposit bridged from the sequencer into the rollup.
+type AstriaDeposit struct {
+	BridgeAddress string // bech32m address of the bridge account on the sequencer
+	AssetDenom    string // denomination of the asset on the sequencer
+	// Asset is the ERC20 contract minting the deposit, or the zero address if the
+	// deposit is minted in the native asset.
+	Asset common.Address
+	// Recipient is the account credited with the deposit, which may be the failed
+	// mint recipient or the refund recipient of the deposit. It is the zero address
+	// if the deposit was not credited to anyone.
+	Recipient              common.Address
+	Amount                 *big.Int // amount credited, in rollup precision
+	SourceTransactionId    string   // hash of the sequencer transaction of the deposit
+	SourceTransactionIndex uint64   // index of the deposit action in its sequencer transaction
+}
+
+// AstriaWithdrawal describes a withdrawal from the rollup to the sequencer, or to an
+// IBC chain via the sequencer, as emitted by a withdrawal event.
+type AstriaWithdrawal struct {
+	BridgeAddress string // bech32m address of the bridge account, if the contract is a known bridge
+	AssetDenom    string // denomination of the asset, if the contract is a known bridge
+	// Contract is the contract that emitted the withdrawal event.
+	Contract                common.Address
+	Sender                  common.Address
+	Amount                  *big.Int
+	DestinationChainAddress string
+	// Memo is only set for withdrawals to an IBC chain.
+	Memo string
+	Ibc  bool
+}
+
 type (
 	/*
 		- VM events -
@@ -167,6 +198,17 @@ type (
 
 	// LogHook is called when a log is emitted.
 	LogHook = func(log *types.Log)
+
+	/*
+		- Astria events -
+	*/
+
+	// AstriaDepositHook is called when a deposit transaction has been executed.
+	AstriaDepositHook = func(deposit *AstriaDeposit)
+
+	// AstriaWithdrawalHook is called for every withdrawal event of a successful
+	// transaction, after its execution and before OnTxEnd.
+	AstriaWithdrawalHook = func(withdrawal *AstriaWithdrawal)
 )
 
 type Hooks struct {
@@ -193,6 +235,9 @@ type Hooks struct {
 	OnCodeChange    CodeChangeHook
 	OnStorageChange StorageChangeHook
 	OnLog           LogHook
+	// Astria events
+	OnAstriaDeposit    AstriaDepositHook
+	OnAstriaWithdrawal AstriaWithdrawalHook
 }
 
 // BalanceChangeReason is used to indicate the reason for a balance change, useful
@@ -245,6 +290,15 @@ const (
 
 	// BalanceIncreaseAstriaDepositTx is ether deposited to the user via an
 	BalanceIncreaseAstriaDepositTx BalanceChangeReason = 15
+	// BalanceDecreaseAstriaFeeSplit is the fees collected by the fee recipient of a block
+	// which are taken back at the end of the block to be split as configured.
+	BalanceDecreaseAstriaFeeSplit BalanceChangeReason = 16
+	// BalanceIncreaseAstriaFeeSplit is the share of the fees of a block paid to a fee split
+	// recipient at the end of the block.
+	BalanceIncreaseAstriaFeeSplit BalanceChangeReason = 17
+	// BalanceIncreaseAstriaBaseFeeVault is the base fee of a transaction credited to the
+	// base fee vault instead of being burned.
+	BalanceIncreaseAstriaBaseFeeVault BalanceChangeReason = 18
 )
 
 // GasChangeReason is used to indicate the reason for a gas change, useful
@@ -102,10 +102,9 @@ var (
 
 	reheapTimer = metrics.NewRegisteredTimer("txpool/reheap", nil)
 
-	// Metrics related to the astria ordered txs
-	astriaValidMeter             = metrics.GetOrRegisterMeter("astria/txpool/valid", nil)
-	astriaExcludedFromBlockMeter = metrics.GetOrRegisterMeter("astria/txpool/excludedFromBlock", nil)
-	astriaRequestedMeter         = metrics.GetOrRegisterMeter("astria/txpool/requested", nil)
+	// Metrics for the transactions retained across optimistic heads in auctioneer mode
+	auctioneerRetainedMeter = metrics.NewRegisteredMeter("txpool/auctioneer/retained", nil)
+	auctioneerEvictedMeter  = metrics.NewRegisteredMeter("txpool/auctioneer/evicted", nil)
 )
 
 // BlockChain defines the minimal set of methods needed to back a tx pool with
@@ -140,6 +139,10 @@ type Config struct {
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+
+	// Number of optimistic heads unexecuted transactions are retained across in
+	// auctioneer mode, zero clears the pool on every optimistic head
+	AuctioneerRetention uint64
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
@@ -214,8 +217,6 @@ type LegacyPool struct {
 	signer           types.Signer
 	mu               sync.RWMutex
 
-	astria *astriaOrdered
-
 	currentHead   atomic.Pointer[types.Header] // Current head of the blockchain
 	currentState  *state.StateDB               // Current state in the blockchain head
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
@@ -230,6 +231,8 @@ type LegacyPool struct {
 	all     *lookup                      // All transactions to allow lookups
 	priced  *pricedList                  // All transactions sorted by price
 
+	retained map[common.Hash]uint64 // Number of optimistic heads each transaction was retained across
+
 	reqResetCh      chan *txpoolResetRequest
 	reqPromoteCh    chan *accountSet
 	queueTxEventCh  chan *types.Transaction
@@ -263,6 +266,7 @@ func New(config Config, chain BlockChain, auctioneerEnabled bool) *LegacyPool {
 		queue:             make(map[common.Address]*list),
 		beats:             make(map[common.Address]time.Time),
 		all:               newLookup(),
+		retained:          make(map[common.Hash]uint64),
 		reqResetCh:        make(chan *txpoolResetRequest),
 		reqPromoteCh:      make(chan *accountSet),
 		queueTxEventCh:    make(chan *types.Transaction),
@@ -284,90 +288,24 @@ func New(config Config, chain BlockChain, auctioneerEnabled bool) *LegacyPool {
 	return pool
 }
 
-type astriaOrdered struct {
-	valid             types.Transactions
-	excludedFromBlock types.Transactions
-	pool              *LegacyPool
-}
-
-func newAstriaOrdered(valid types.Transactions, pool *LegacyPool) *astriaOrdered {
-	astriaValidMeter.Mark(int64(len(valid)))
-
-	return &astriaOrdered{
-		valid:             valid,
-		excludedFromBlock: types.Transactions{},
-		pool:              pool,
-	}
-}
-
-func (ao *astriaOrdered) clear() {
-	ao.valid = types.Transactions{}
-	ao.excludedFromBlock = types.Transactions{}
-}
-
 func (pool *LegacyPool) ValidateTx(tx *types.Transaction) error {
 	return pool.validateTxBasics(tx, false)
 }
 
-func (pool *LegacyPool) SetAstriaOrdered(txs types.Transactions) {
-	astriaRequestedMeter.Mark(int64(len(txs)))
-
-	valid := []*types.Transaction{}
-	for idx, tx := range txs {
-		err := pool.validateTxBasics(tx, false)
-		if err != nil {
-			log.Warn("astria tx failed validation", "index", idx, "hash", tx.Hash(), "error", err)
-			continue
-		}
-
-		valid = append(valid, tx)
-	}
-
-	pool.astria = newAstriaOrdered(valid, pool)
-}
-
-func (pool *LegacyPool) AddToAstriaExcludedFromBlock(tx *types.Transaction) {
-	if pool.astria.excludedFromBlock == nil {
-		pool.astria.excludedFromBlock = types.Transactions{tx}
-		return
-	}
-
-	pool.astria.excludedFromBlock = append(pool.astria.excludedFromBlock, tx)
-}
-
-func (pool *LegacyPool) AstriaExcludedFromBlock() *types.Transactions {
-	if pool.astria == nil {
-		return &types.Transactions{}
-	}
-	return &pool.astria.excludedFromBlock
-}
-
-func (pool *LegacyPool) ClearAstriaOrdered() {
-	if pool.astria == nil {
-		return
-	}
+// Remove evicts the given transactions from the pool, returning the number of
+// transactions which were removed. Transactions of the same sender with higher nonces
+// are moved back to the queue.
+func (pool *LegacyPool) Remove(txs types.Transactions) int {
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
-	astriaExcludedFromBlockMeter.Mark(int64(len(pool.astria.excludedFromBlock)))
-	for _, tx := range pool.astria.excludedFromBlock {
-		n := pool.removeTx(tx.Hash(), false, true)
-		if n == 0 {
-			log.Trace("astria tx excluded from block not found in mempool", "hash", tx.Hash())
-		} else {
-			log.Trace("astria tx excluded from block removed from mempool", "hash", tx.Hash())
+	var removed int
+	for _, tx := range txs {
+		if pool.removeTx(tx.Hash(), false, true) > 0 {
+			removed++
 		}
 	}
-
-	pool.astria.clear()
-}
-
-func (pool *LegacyPool) AstriaOrdered() *types.Transactions {
-	// sus but whatever
-	if pool.astria == nil {
-		return &types.Transactions{}
-	}
-	return &pool.astria.valid
+	return removed
 }
 
 // Filter returns whether the given transaction can be consumed by the legacy
@@ -1404,11 +1342,20 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	// Check for pending transactions for every account that sent new ones
 	promoted := pool.promoteExecutables(promoteAddrs)
 
+	// Transactions retained across a new head are not new to the pool, so they are not
+	// announced again but reported along with the new head
+	var retained []*types.Transaction
+
 	// If a new block appeared, validate the pool of pending transactions. This will
 	// remove any transaction that has been included in the block or was invalidated
 	// because of another transaction (e.g. higher gas price).
 	if reset != nil {
-		if pool.auctioneerEnabled {
+		if pool.auctioneerEnabled && pool.config.AuctioneerRetention > 0 {
+			// if we are running the pool as an auctioneer with retention, then we keep the transactions
+			// which are still valid against the optimistic state for a limited number of heads
+			pool.demoteUnexecutables()
+			retained = pool.retainUnexecuted()
+		} else if pool.auctioneerEnabled {
 			// if we are running the pool as an auctioneer, then we should clear the mempool each time the head
 			// is reset
 			pool.clearPendingAndQueued()
@@ -1442,7 +1389,7 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 
 	// Notify that the mempool has been cleared
 	if reset != nil {
-		pool.mempoolClearFeed.Send(core.NewMempoolCleared{NewHead: reset.newHead})
+		pool.mempoolClearFeed.Send(core.NewMempoolCleared{NewHead: reset.newHead, Retained: retained})
 	}
 
 	// Notify subsystems for newly added transactions
@@ -1829,6 +1776,39 @@ func (pool *LegacyPool) clearPendingAndQueued() {
 	}
 }
 
+// retainUnexecuted counts another optimistic head for all transactions left in the pool,
+// evicting the ones which have been retained for more than the configured number of heads.
+// It returns the retained pending transactions and assumes that the pool lock is being held.
+func (pool *LegacyPool) retainUnexecuted() []*types.Transaction {
+	var evict []common.Hash
+	retained := make(map[common.Hash]uint64, pool.all.Count())
+	pool.all.Range(func(hash common.Hash, tx *types.Transaction, local bool) bool {
+		rounds := pool.retained[hash] + 1
+		if rounds > pool.config.AuctioneerRetention {
+			evict = append(evict, hash)
+		} else {
+			retained[hash] = rounds
+		}
+		return true
+	}, true, true)
+	for _, hash := range evict {
+		pool.removeTx(hash, true, true)
+		delete(retained, hash)
+		log.Trace("Evicted unexecuted transaction", "hash", hash)
+	}
+	pool.retained = retained
+
+	// Removing a transaction may have demoted the later ones of its sender
+	var pending []*types.Transaction
+	for _, list := range pool.pending {
+		pending = append(pending, list.Flatten()...)
+	}
+
+	auctioneerRetainedMeter.Mark(int64(len(retained)))
+	auctioneerEvictedMeter.Mark(int64(len(evict)))
+	return pending
+}
+
 // demoteUnexecutables removes invalid and processed transactions from the pools
 // executable/pending queue and any subsequent transactions that become unexecutable
 // are moved back into the future queue.
@@ -2589,3 +2589,104 @@ func BenchmarkMultiAccountBatchInsert(b *testing.B) {
 		pool.addRemotesSync([]*types.Transaction{tx})
 	}
 }
+
+// Tests that removing a transaction evicts it from the pool and demotes the later
+// transactions of its sender.
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool(false)
+	defer pool.Close()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+	txs := types.Transactions{
+		transaction(0, 100000, key),
+		transaction(1, 100000, key),
+		transaction(2, 100000, key),
+	}
+	for _, err := range pool.addRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	if removed := pool.Remove(types.Transactions{txs[1], pricedTransaction(5, 100000, big.NewInt(1), key)}); removed != 1 {
+		t.Fatalf("unexpected number of removed transactions: have %d, want 1", removed)
+	}
+	if pool.Get(txs[1].Hash()) != nil {
+		t.Errorf("removed transaction still in pool")
+	}
+	if pending, queued := pool.Stats(); pending != 1 || queued != 1 {
+		t.Errorf("unexpected pool stats: have %d pending and %d queued, want 1 and 1", pending, queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that in auctioneer mode with retention, unexecuted transactions are kept across
+// the configured number of heads and evicted afterwards.
+func TestAuctioneerRetention(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.AuctioneerRetention = 2
+	pool := New(config, blockchain, true)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, addr, big.NewInt(1000000000))
+
+	events := make(chan core.NewTxsEvent, 4)
+	sub := pool.txFeed.Subscribe(events)
+	defer sub.Unsubscribe()
+	cleared := make(chan core.NewMempoolCleared, 4)
+	clearedSub := pool.SubscribeMempoolClearance(cleared)
+	defer clearedSub.Unsubscribe()
+
+	txs := types.Transactions{transaction(0, 100000, key), transaction(1, 100000, key)}
+	for _, err := range pool.addRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	if err := validateEvents(events, 2); err != nil {
+		t.Fatalf("original event firing failed: %v", err)
+	}
+	// Both transactions are retained across the first head, and reported with the head
+	// instead of being announced again
+	<-pool.requestReset(nil, nil)
+	if pending, queued := pool.Stats(); pending != 2 || queued != 0 {
+		t.Fatalf("unexpected pool stats after first head: have %d pending and %d queued, want 2 and 0", pending, queued)
+	}
+	if err := validateEvents(events, 0); err != nil {
+		t.Fatalf("retained transactions announced again: %v", err)
+	}
+	if ev := <-cleared; len(ev.Retained) != 2 {
+		t.Fatalf("unexpected retained transactions after first head: have %d, want 2", len(ev.Retained))
+	}
+	// The first transaction is executed, the second one is retained across the second head
+	statedb.SetNonce(addr, 1)
+	<-pool.requestReset(nil, nil)
+	if pending, queued := pool.Stats(); pending != 1 || queued != 0 {
+		t.Fatalf("unexpected pool stats after second head: have %d pending and %d queued, want 1 and 0", pending, queued)
+	}
+	if pool.Get(txs[1].Hash()) == nil {
+		t.Fatalf("unexecuted transaction not retained")
+	}
+	if ev := <-cleared; len(ev.Retained) != 1 || ev.Retained[0].Hash() != txs[1].Hash() {
+		t.Fatalf("unexpected retained transactions after second head: %v", ev.Retained)
+	}
+	// The second transaction is evicted on the third head
+	<-pool.requestReset(nil, nil)
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("unexpected pool stats after third head: have %d pending and %d queued, want 0 and 0", pending, queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
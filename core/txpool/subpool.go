@@ -166,11 +166,9 @@ type SubPool interface {
 	// identified by their hashes.
 	Status(hash common.Hash) TxStatus
 
-	SetAstriaOrdered(types.Transactions)
-	ClearAstriaOrdered()
-	AddToAstriaExcludedFromBlock(tx *types.Transaction)
-	AstriaExcludedFromBlock() *types.Transactions
-	AstriaOrdered() *types.Transactions
+	// Remove evicts the given transactions from the pool, returning the number of
+	// transactions which were removed.
+	Remove(txs types.Transactions) int
 
 	ValidateTx(tx *types.Transaction) error
 }
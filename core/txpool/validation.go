@@ -120,6 +120,11 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 		if tx.GasTipCapIntCmp(opts.MinTip) < 0 {
 			return fmt.Errorf("%w: tip needed %v, tip permitted %v", ErrUnderpriced, opts.MinTip, tx.GasTipCap())
 		}
+		// Ensure the gasprice is high enough to cover the minimum priority fee of the
+		// next block, as it would never be included otherwise
+		if minTip := opts.Config.AstriaMinPriorityFee(head.Number.Uint64() + 1); tx.GasTipCapIntCmp(minTip) < 0 {
+			return fmt.Errorf("%w: tip needed %v, tip permitted %v", ErrUnderpriced, minTip, tx.GasTipCap())
+		}
 	}
 	if tx.Type() == types.BlobTxType {
 		// Ensure the blob fee cap satisfies the minimum blob gas price
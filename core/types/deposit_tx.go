@@ -30,6 +30,13 @@ type DepositTx struct {
 	SourceTransactionId primitivev1.TransactionId
 	// index of the deposit's source action within its transaction
 	SourceTransactionIndex uint64
+	// if this is an ERC20 mint and the bridge has a failed mint recipient, this
+	// is set to the calldata minting to that recipient. it is executed if the
+	// call with `Data` fails.
+	FallbackData []byte `rlp:"optional"`
+	// if this is a native asset deposit calling a contract, the deposited value
+	// is transferred to this address if the call fails.
+	RefundRecipient common.Address `rlp:"optional"`
 }
 
 func (tx *DepositTx) copy() TxData {
@@ -46,6 +53,10 @@ func (tx *DepositTx) copy() TxData {
 		Data:  make([]byte, len(tx.Data)),
 		SourceTransactionId: tx.SourceTransactionId,
 		SourceTransactionIndex: tx.SourceTransactionIndex,
+		RefundRecipient: tx.RefundRecipient,
+	}
+	if tx.FallbackData != nil {
+		cpy.FallbackData = common.CopyBytes(tx.FallbackData)
 	}
 
 	if tx.Value != nil {
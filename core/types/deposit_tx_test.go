@@ -0,0 +1,113 @@
+// Copyright 2014 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	primitivev1 "buf.build/gen/go/astria/primitives/protocolbuffers/go/astria/primitive/v1"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func testDepositTxs() map[string]*Transaction {
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	return map[string]*Transaction{
+		"native": NewTx(&DepositTx{
+			From:                   common.HexToAddress("0x00000000000000000000000000000000000000bb"),
+			Value:                  big.NewInt(1000000000),
+			Gas:                    64000,
+			To:                     &to,
+			Data:                   []byte{},
+			SourceTransactionId:    primitivev1.TransactionId{Inner: "90d6ba9fd3d53a3d4357d6bf4fc56a29ac48e71f0e3d1b8b3d8d2e9f77d7d2a1"},
+			SourceTransactionIndex: 2,
+		}),
+		"erc20": NewTx(&DepositTx{
+			From:                   common.HexToAddress("0x00000000000000000000000000000000000000bb"),
+			Value:                  new(big.Int),
+			Gas:                    100000,
+			To:                     &to,
+			Data:                   common.FromHex("0x40c10f19"),
+			SourceTransactionId:    primitivev1.TransactionId{Inner: "1fb3c6e4f0d2a9f1b7d8c3a2e5f6a7b8c9d0e1f2a3b4c5d6e7f8091a2b3c4d5e"},
+			FallbackData:           common.FromHex("0x40c10f1a"),
+			RefundRecipient:        common.HexToAddress("0x00000000000000000000000000000000000000cc"),
+			SourceTransactionIndex: 0,
+		}),
+	}
+}
+
+func TestDepositTxJSON(t *testing.T) {
+	for name, tx := range testDepositTxs() {
+		data, err := json.MarshalIndent(tx, "", "  ")
+		if err != nil {
+			t.Fatalf("%s: json encoding failed: %v", name, err)
+		}
+		file := filepath.Join("testdata", "deposit_tx-"+name+".json")
+		if os.Getenv("WRITE_TEST_FILES") != "" {
+			os.WriteFile(file, data, 0644)
+		}
+		want, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("%s: error reading expected test file: %v", name, err)
+		}
+		if !bytes.Equal(bytes.TrimSpace(want), data) {
+			t.Errorf("%s: json mismatch, want:\n%s\nhave:\n%s", name, want, data)
+		}
+
+		parsedTx, err := encodeDecodeJSON(tx)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := assertEqual(parsedTx, tx); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if parsedTx.From() != tx.From() {
+			t.Errorf("%s: from mismatch, want %v, got %v", name, tx.From(), parsedTx.From())
+		}
+		if parsedTx.DepositSourceTransactionId() != tx.DepositSourceTransactionId() {
+			t.Errorf("%s: source transaction id mismatch, want %s, got %s", name, tx.DepositSourceTransactionId(), parsedTx.DepositSourceTransactionId())
+		}
+		if parsedTx.DepositSourceTransactionIndex() != tx.DepositSourceTransactionIndex() {
+			t.Errorf("%s: source transaction index mismatch, want %d, got %d", name, tx.DepositSourceTransactionIndex(), parsedTx.DepositSourceTransactionIndex())
+		}
+		if !bytes.Equal(parsedTx.DepositFallbackData(), tx.DepositFallbackData()) {
+			t.Errorf("%s: fallback data mismatch", name)
+		}
+		if parsedTx.DepositRefundRecipient() != tx.DepositRefundRecipient() {
+			t.Errorf("%s: refund recipient mismatch", name)
+		}
+	}
+}
+
+func TestDepositTxJSONMissingFields(t *testing.T) {
+	for _, field := range []string{"from", "gas", "value", "input", "sourceTransactionId", "sourceTransactionIndex"} {
+		data, _ := json.Marshal(testDepositTxs()["native"])
+		var fields map[string]interface{}
+		json.Unmarshal(data, &fields)
+		delete(fields, field)
+		data, _ = json.Marshal(fields)
+
+		var tx Transaction
+		if err := json.Unmarshal(data, &tx); err == nil {
+			t.Errorf("expected error decoding deposit without %s", field)
+		}
+	}
+}
@@ -114,6 +114,50 @@ func (tx *Transaction) From() common.Address {
 	return deposit.From
 }
 
+// DepositFallbackData returns the calldata executed if the call of a deposit
+// transaction fails. It is only set for deposit transactions.
+func (tx *Transaction) DepositFallbackData() []byte {
+	if tx.Type() != DepositTxType {
+		return nil
+	}
+
+	deposit := tx.inner.(*DepositTx)
+	return common.CopyBytes(deposit.FallbackData)
+}
+
+// DepositRefundRecipient returns the address receiving the value of a deposit
+// transaction whose call fails. It is only set for deposit transactions.
+func (tx *Transaction) DepositRefundRecipient() common.Address {
+	if tx.Type() != DepositTxType {
+		return common.Address{}
+	}
+
+	deposit := tx.inner.(*DepositTx)
+	return deposit.RefundRecipient
+}
+
+// DepositSourceTransactionId returns the hash of the sequencer transaction containing
+// the source action of a deposit transaction. It is only set for deposit transactions.
+func (tx *Transaction) DepositSourceTransactionId() string {
+	if tx.Type() != DepositTxType {
+		return ""
+	}
+
+	deposit := tx.inner.(*DepositTx)
+	return deposit.SourceTransactionId.GetInner()
+}
+
+// DepositSourceTransactionIndex returns the index of the source action of a deposit
+// transaction within its sequencer transaction. It is only set for deposit transactions.
+func (tx *Transaction) DepositSourceTransactionIndex() uint64 {
+	if tx.Type() != DepositTxType {
+		return 0
+	}
+
+	deposit := tx.inner.(*DepositTx)
+	return deposit.SourceTransactionIndex
+}
+
 // EncodeRLP implements rlp.Encoder
 func (tx *Transaction) EncodeRLP(w io.Writer) error {
 	if tx.Type() == LegacyTxType {
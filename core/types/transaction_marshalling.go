@@ -53,6 +53,13 @@ type txJSON struct {
 	Commitments []kzg4844.Commitment `json:"commitments,omitempty"`
 	Proofs      []kzg4844.Proof      `json:"proofs,omitempty"`
 
+	// Deposit transaction fields:
+	From                   *common.Address `json:"from,omitempty"`
+	SourceTransactionId    *string         `json:"sourceTransactionId,omitempty"`
+	SourceTransactionIndex *hexutil.Uint64 `json:"sourceTransactionIndex,omitempty"`
+	FallbackData           *hexutil.Bytes  `json:"fallbackData,omitempty"`
+	RefundRecipient        *common.Address `json:"refundRecipient,omitempty"`
+
 	// Only used for encoding:
 	Hash common.Hash `json:"hash"`
 }
@@ -153,6 +160,30 @@ func (tx *Transaction) MarshalJSON() ([]byte, error) {
 			enc.Commitments = itx.Sidecar.Commitments
 			enc.Proofs = itx.Sidecar.Proofs
 		}
+
+	case *DepositTx:
+		// deposits are not signed and do not pay for gas, the zero values are set
+		// for the clients expecting these fields.
+		v, r, s := itx.rawSignatureValues()
+		enc.Nonce = new(hexutil.Uint64)
+		enc.GasPrice = (*hexutil.Big)(itx.gasPrice())
+		enc.V = (*hexutil.Big)(v)
+		enc.R = (*hexutil.Big)(r)
+		enc.S = (*hexutil.Big)(s)
+		enc.From = &itx.From
+		enc.To = tx.To()
+		enc.Gas = (*hexutil.Uint64)(&itx.Gas)
+		enc.Value = (*hexutil.Big)(itx.Value)
+		enc.Input = (*hexutil.Bytes)(&itx.Data)
+		sourceTransactionId := itx.SourceTransactionId.GetInner()
+		enc.SourceTransactionId = &sourceTransactionId
+		enc.SourceTransactionIndex = (*hexutil.Uint64)(&itx.SourceTransactionIndex)
+		if len(itx.FallbackData) > 0 {
+			enc.FallbackData = (*hexutil.Bytes)(&itx.FallbackData)
+		}
+		if itx.RefundRecipient != (common.Address{}) {
+			enc.RefundRecipient = &itx.RefundRecipient
+		}
 	}
 	return json.Marshal(&enc)
 }
@@ -409,6 +440,43 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 			}
 		}
 
+	case DepositTxType:
+		var itx DepositTx
+		inner = &itx
+		if dec.From == nil {
+			return errors.New("missing required field 'from' in transaction")
+		}
+		itx.From = *dec.From
+		if dec.To != nil {
+			itx.To = dec.To
+		}
+		if dec.Gas == nil {
+			return errors.New("missing required field 'gas' in transaction")
+		}
+		itx.Gas = uint64(*dec.Gas)
+		if dec.Value == nil {
+			return errors.New("missing required field 'value' in transaction")
+		}
+		itx.Value = (*big.Int)(dec.Value)
+		if dec.Input == nil {
+			return errors.New("missing required field 'input' in transaction")
+		}
+		itx.Data = *dec.Input
+		if dec.SourceTransactionId == nil {
+			return errors.New("missing required field 'sourceTransactionId' in transaction")
+		}
+		itx.SourceTransactionId.Inner = *dec.SourceTransactionId
+		if dec.SourceTransactionIndex == nil {
+			return errors.New("missing required field 'sourceTransactionIndex' in transaction")
+		}
+		itx.SourceTransactionIndex = uint64(*dec.SourceTransactionIndex)
+		if dec.FallbackData != nil {
+			itx.FallbackData = *dec.FallbackData
+		}
+		if dec.RefundRecipient != nil {
+			itx.RefundRecipient = *dec.RefundRecipient
+		}
+
 	default:
 		return ErrTxTypeNotSupported
 	}
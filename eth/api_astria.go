@@ -0,0 +1,224 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// AstriaAPI provides an API to inspect the Astria specific state of the rollup.
+type AstriaAPI struct {
+	e *Ethereum
+}
+
+// NewAstriaAPI creates a new AstriaAPI instance.
+func NewAstriaAPI(e *Ethereum) *AstriaAPI {
+	return &AstriaAPI{e}
+}
+
+// AuctioneerSet is the set of auctioneers whose allocations are valid at a height.
+type AuctioneerSet struct {
+	Height    hexutil.Uint64 `json:"height"`
+	Addresses []string       `json:"addresses"`
+}
+
+// AuctioneerAddresses returns the bech32m addresses of the auctioneers whose allocations
+// are valid at the given height, or at the next block if no height is given. The set is
+// empty if auctions are disabled at that height.
+func (api *AstriaAPI) AuctioneerAddresses(height *hexutil.Uint64) AuctioneerSet {
+	h := api.e.BlockChain().CurrentBlock().Number.Uint64() + 1
+	if height != nil {
+		h = uint64(*height)
+	}
+	return AuctioneerSet{
+		Height:    hexutil.Uint64(h),
+		Addresses: api.e.BlockChain().Config().AstriaAuctioneerAddressesAt(h),
+	}
+}
+
+// GasUsage is the split of the gas of a block between deposit and user transactions.
+type GasUsage struct {
+	Number           hexutil.Uint64 `json:"number"`
+	GasLimit         hexutil.Uint64 `json:"gasLimit"`
+	DepositGasLimit  hexutil.Uint64 `json:"depositGasLimit"`
+	DepositGasUsed   hexutil.Uint64 `json:"depositGasUsed"`
+	UserGasLimit     hexutil.Uint64 `json:"userGasLimit"`
+	UserGasUsed      hexutil.Uint64 `json:"userGasUsed"`
+	UserGasRemaining hexutil.Uint64 `json:"userGasRemaining"`
+}
+
+// GasUsage returns the gas used by deposit and user transactions in the given block, and
+// the gas that was left for user transactions after the gas reserved for deposits.
+func (api *AstriaAPI) GasUsage(blockNr rpc.BlockNumber) (*GasUsage, error) {
+	header, err := api.header(blockNr)
+	if err != nil {
+		return nil, err
+	}
+	block := api.e.blockchain.GetBlock(header.Hash(), header.Number.Uint64())
+	if block == nil {
+		return nil, fmt.Errorf("block #%d not found", header.Number)
+	}
+	receipts := api.e.blockchain.GetReceiptsByHash(block.Hash())
+	if len(receipts) != len(block.Transactions()) {
+		return nil, fmt.Errorf("receipts of block #%d not found", header.Number)
+	}
+	usage := core.NewAstriaGasUsage(api.e.blockchain.Config(), header, block.Transactions(), receipts)
+	return &GasUsage{
+		Number:           hexutil.Uint64(header.Number.Uint64()),
+		GasLimit:         hexutil.Uint64(header.GasLimit),
+		DepositGasLimit:  hexutil.Uint64(usage.DepositGasLimit),
+		DepositGasUsed:   hexutil.Uint64(usage.DepositGasUsed),
+		UserGasLimit:     hexutil.Uint64(usage.UserGasLimit),
+		UserGasUsed:      hexutil.Uint64(usage.UserGasUsed),
+		UserGasRemaining: hexutil.Uint64(usage.UserGasRemaining),
+	}, nil
+}
+
+// header returns the header of the given block, which must not be pending.
+func (api *AstriaAPI) header(blockNr rpc.BlockNumber) (*types.Header, error) {
+	var header *types.Header
+	switch blockNr {
+	case rpc.PendingBlockNumber:
+		return nil, errors.New("pending block is not supported")
+	case rpc.LatestBlockNumber:
+		header = api.e.blockchain.CurrentBlock()
+	case rpc.FinalizedBlockNumber:
+		header = api.e.blockchain.CurrentFinalBlock()
+	case rpc.SafeBlockNumber:
+		header = api.e.blockchain.CurrentSafeBlock()
+	default:
+		header = api.e.blockchain.GetHeaderByNumber(uint64(blockNr))
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block #%d not found", blockNr)
+	}
+	return header, nil
+}
+
+// BridgeSupply is the supply of the asset of a bridge on the rollup, in rollup precision.
+type BridgeSupply struct {
+	BridgeAddress string          `json:"bridgeAddress"`
+	AssetDenom    string          `json:"assetDenom"`
+	Erc20Asset    *common.Address `json:"erc20Asset,omitempty"`
+	// Since is the number of the first block the node accumulated the totals of the
+	// bridges from.
+	Since hexutil.Uint64 `json:"since"`
+	// Minted and Burned are the totals of the deposits and withdrawals of the bridge,
+	// since the node started accumulating them.
+	Minted *hexutil.Big `json:"minted"`
+	Burned *hexutil.Big `json:"burned"`
+	// Supply is the `totalSupply` of the ERC20 contract of the bridge. For a native asset,
+	// it is the minted amount net of the growth of the balance of the withdrawer since the
+	// node started accumulating the totals.
+	Supply *hexutil.Big `json:"supply"`
+}
+
+// BridgeSupply returns the supply of the asset of every bridge active at the given block,
+// so that it can be reconciled against the funds locked by the bridge account on the
+// sequencer.
+func (api *AstriaAPI) BridgeSupply(ctx context.Context, blockNr rpc.BlockNumber) ([]*BridgeSupply, error) {
+	header, err := api.header(blockNr)
+	if err != nil {
+		return nil, err
+	}
+	accumulated, err := core.ReadAstriaBridgeSupply(api.e.chainDb, header.Hash())
+	if err != nil {
+		return nil, err
+	}
+	block := rpc.BlockNumberOrHashWithHash(header.Hash(), false)
+	statedb, _, err := api.e.APIBackend.StateAndHeaderByNumberOrHash(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	caller := &astriaContractCaller{backend: api.e.APIBackend, block: block}
+
+	supplies := []*BridgeSupply{}
+	for _, bridge := range api.e.blockchain.Config().AstriaBridgeAddressConfigs {
+		if header.Number.Uint64() < uint64(bridge.StartHeight) {
+			continue
+		}
+		supply := &BridgeSupply{
+			BridgeAddress: bridge.BridgeAddress,
+			AssetDenom:    bridge.AssetDenom,
+			Since:         hexutil.Uint64(accumulated.Since),
+			Minted:        new(hexutil.Big),
+			Burned:        new(hexutil.Big),
+		}
+		if totals, ok := accumulated.Bridges[bridge.BridgeAddress]; ok {
+			supply.Minted = (*hexutil.Big)(totals.Minted)
+			supply.Burned = (*hexutil.Big)(totals.Burned)
+		}
+		if bridge.Erc20Asset != nil {
+			contract := bridge.Erc20Asset.ContractAddress
+			supply.Erc20Asset = &contract
+			token, err := contracts.NewAstriaBridgeableERC20Caller(contract, caller)
+			if err != nil {
+				return nil, err
+			}
+			totalSupply, err := token.TotalSupply(&bind.CallOpts{Context: ctx})
+			if err != nil {
+				return nil, fmt.Errorf("failed to call totalSupply of %s: %w", contract, err)
+			}
+			supply.Supply = (*hexutil.Big)(totalSupply)
+		} else {
+			withdrawn := accumulated.WithdrawnNative(&bridge, statedb)
+			supply.Supply = (*hexutil.Big)(new(big.Int).Sub(supply.Minted.ToInt(), withdrawn))
+		}
+		supplies = append(supplies, supply)
+	}
+	return supplies, nil
+}
+
+// astriaContractCaller implements bind.ContractCaller against the state of a fixed block.
+type astriaContractCaller struct {
+	backend ethapi.Backend
+	block   rpc.BlockNumberOrHash
+}
+
+func (c *astriaContractCaller) CodeAt(ctx context.Context, contract common.Address, _ *big.Int) ([]byte, error) {
+	statedb, _, err := c.backend.StateAndHeaderByNumberOrHash(ctx, c.block)
+	if err != nil {
+		return nil, err
+	}
+	return statedb.GetCode(contract), nil
+}
+
+func (c *astriaContractCaller) CallContract(ctx context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	data := hexutil.Bytes(call.Data)
+	args := ethapi.TransactionArgs{From: &call.From, To: call.To, Data: &data}
+	result, err := ethapi.DoCall(ctx, c.backend, args, c.block, nil, nil, c.backend.RPCEVMTimeout(), c.backend.RPCGasCap())
+	if err != nil {
+		return nil, err
+	}
+	if result.Failed() {
+		return nil, result.Err
+	}
+	return result.Return(), nil
+}
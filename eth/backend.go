@@ -323,6 +323,9 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "net",
 			Service:   s.netRPCService,
+		}, {
+			Namespace: "astria",
+			Service:   NewAstriaAPI(s),
 		},
 	}...)
 }
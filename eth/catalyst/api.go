@@ -135,6 +135,9 @@ type ConsensusAPI struct {
 
 	forkchoiceLock sync.Mutex // Lock for the forkChoiceUpdated method
 	newPayloadLock sync.Mutex // Lock for the NewPayload method
+
+	astriaOrdered     types.Transactions // Transactions included in the payloads built, in order
+	astriaOrderedLock sync.Mutex         // Protects the astria ordered transactions
 }
 
 // NewConsensusAPI creates a new consensus api for the given backend.
@@ -388,6 +391,7 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 				return valid(nil), engine.InvalidPayloadAttributes.With(err)
 			}
 		}
+		args.Transactions = api.AstriaOrdered()
 		payload, err := api.eth.Miner().BuildPayload(args)
 		if err != nil {
 			log.Error("Failed to build payload", "err", err)
@@ -399,6 +403,23 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 	return valid(nil), nil
 }
 
+// SetAstriaOrdered sets the transactions, in order, included in the payloads built over
+// the engine API.
+func (api *ConsensusAPI) SetAstriaOrdered(txs types.Transactions) {
+	api.astriaOrderedLock.Lock()
+	defer api.astriaOrderedLock.Unlock()
+
+	api.astriaOrdered = txs
+}
+
+// AstriaOrdered returns the transactions included in the payloads built over the engine API.
+func (api *ConsensusAPI) AstriaOrdered() types.Transactions {
+	api.astriaOrderedLock.Lock()
+	defer api.astriaOrderedLock.Unlock()
+
+	return api.astriaOrdered
+}
+
 // ExchangeTransitionConfigurationV1 checks the given configuration against
 // the configuration of the node.
 func (api *ConsensusAPI) ExchangeTransitionConfigurationV1(config engine.TransitionConfigurationV1) (*engine.TransitionConfigurationV1, error) {
@@ -114,7 +114,7 @@ func TestSimulatedBeaconSendWithdrawals(t *testing.T) {
 		allTxs = append(allTxs, tx)
 	}
 
-	ethService.TxPool().SetAstriaOrdered(allTxs)
+	mock.engineAPI.SetAstriaOrdered(allTxs)
 
 	includedTxs := make(map[common.Hash]struct{})
 	var includedWithdrawals []uint64
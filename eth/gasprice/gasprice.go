@@ -215,6 +215,11 @@ func (oracle *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
 	if price.Cmp(oracle.maxPrice) > 0 {
 		price = new(big.Int).Set(oracle.maxPrice)
 	}
+	// Never suggest a tip below the minimum priority fee of the next block, as
+	// transactions paying it would be rejected.
+	if minTip := oracle.backend.ChainConfig().AstriaMinPriorityFee(head.Number.Uint64() + 1); price.Cmp(minTip) < 0 {
+		price = minTip
+	}
 	oracle.cacheLock.Lock()
 	oracle.lastHead = headHash
 	oracle.lastPrice = price
@@ -236,3 +236,29 @@ func TestSuggestTipCap(t *testing.T) {
 		}
 	}
 }
+
+func TestSuggestTipCapAstriaMinPriorityFee(t *testing.T) {
+	config := Config{
+		Blocks:     3,
+		Percentile: 60,
+		Default:    big.NewInt(params.GWei),
+	}
+	backend := newTestBackend(t, big.NewInt(0), nil, false)
+	defer backend.teardown()
+
+	// The minimum priority fee only applies from the block following the head
+	backend.chain.Config().AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+		0:            {ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator},
+		testHead + 1: {ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator, MinPriorityFee: 40 * params.GWei},
+	})
+	oracle := NewOracle(backend, config)
+
+	// The gas price sampled is: 32G, 31G, 30G, 29G, 28G, 27G
+	got, err := oracle.SuggestTipCap(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to retrieve recommended gas price: %v", err)
+	}
+	if expect := big.NewInt(40 * params.GWei); got.Cmp(expect) != 0 {
+		t.Fatalf("Gas price mismatch, want %d, got %d", expect, got)
+	}
+}
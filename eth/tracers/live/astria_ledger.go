@@ -0,0 +1,169 @@
+package live
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func init() {
+	tracers.LiveDirectory.Register("astriaLedger", newAstriaLedgerTracer)
+}
+
+const (
+	ledgerDeposit    = "deposit"
+	ledgerWithdrawal = "withdrawal"
+)
+
+// ledgerEntry is a line of the bridge flow ledger.
+type ledgerEntry struct {
+	Kind        string      `json:"kind"`
+	BlockNumber uint64      `json:"blockNumber"`
+	BlockHash   common.Hash `json:"blockHash"`
+	TxHash      common.Hash `json:"txHash"`
+
+	BridgeAddress string          `json:"bridgeAddress,omitempty"`
+	AssetDenom    string          `json:"assetDenom,omitempty"`
+	Asset         *common.Address `json:"asset,omitempty"`
+	Amount        *hexutil.Big    `json:"amount"`
+
+	// Deposit fields
+	Recipient              *common.Address `json:"recipient,omitempty"`
+	SourceTransactionId    string          `json:"sourceTransactionId,omitempty"`
+	SourceTransactionIndex *hexutil.Uint64 `json:"sourceTransactionIndex,omitempty"`
+
+	// Withdrawal fields
+	Sender                  *common.Address `json:"sender,omitempty"`
+	DestinationChainAddress string          `json:"destinationChainAddress,omitempty"`
+	Memo                    string          `json:"memo,omitempty"`
+	Ibc                     bool            `json:"ibc,omitempty"`
+}
+
+type astriaLedgerTracerConfig struct {
+	Path string `json:"path"` // File to append the ledger to
+}
+
+// astriaLedger is a live tracer which appends the deposits and withdrawals of every
+// processed block to a JSONL file, for reconciliation of the rollup against the bridge
+// accounts on the sequencer. The entries of a block are only written once the block was
+// processed without error, and blocks which do not end up canonical are written as well.
+type astriaLedger struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+
+	block   *types.Block
+	txHash  common.Hash
+	entries []*ledgerEntry
+}
+
+func newAstriaLedgerTracer(cfg json.RawMessage) (*tracing.Hooks, error) {
+	var config astriaLedgerTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	if config.Path == "" {
+		return nil, errors.New("astria ledger tracer requires a path")
+	}
+	file, err := os.OpenFile(config.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	t := &astriaLedger{file: file, encoder: json.NewEncoder(file)}
+	return &tracing.Hooks{
+		OnBlockStart:       t.OnBlockStart,
+		OnBlockEnd:         t.OnBlockEnd,
+		OnTxStart:          t.OnTxStart,
+		OnClose:            t.OnClose,
+		OnAstriaDeposit:    t.OnAstriaDeposit,
+		OnAstriaWithdrawal: t.OnAstriaWithdrawal,
+	}, nil
+}
+
+func (t *astriaLedger) OnBlockStart(ev tracing.BlockEvent) {
+	t.block = ev.Block
+	t.entries = t.entries[:0]
+}
+
+func (t *astriaLedger) OnTxStart(vm *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.txHash = tx.Hash()
+}
+
+func (t *astriaLedger) OnAstriaDeposit(deposit *tracing.AstriaDeposit) {
+	entry := &ledgerEntry{
+		Kind:                   ledgerDeposit,
+		BridgeAddress:          deposit.BridgeAddress,
+		AssetDenom:             deposit.AssetDenom,
+		Amount:                 (*hexutil.Big)(new(big.Int).Set(deposit.Amount)),
+		Recipient:              &deposit.Recipient,
+		SourceTransactionId:    deposit.SourceTransactionId,
+		SourceTransactionIndex: (*hexutil.Uint64)(&deposit.SourceTransactionIndex),
+	}
+	if deposit.Asset != (common.Address{}) {
+		entry.Asset = &deposit.Asset
+	}
+	t.addEntry(entry)
+}
+
+func (t *astriaLedger) OnAstriaWithdrawal(withdrawal *tracing.AstriaWithdrawal) {
+	t.addEntry(&ledgerEntry{
+		Kind:                    ledgerWithdrawal,
+		BridgeAddress:           withdrawal.BridgeAddress,
+		AssetDenom:              withdrawal.AssetDenom,
+		Asset:                   &withdrawal.Contract,
+		Amount:                  (*hexutil.Big)(new(big.Int).Set(withdrawal.Amount)),
+		Sender:                  &withdrawal.Sender,
+		DestinationChainAddress: withdrawal.DestinationChainAddress,
+		Memo:                    withdrawal.Memo,
+		Ibc:                     withdrawal.Ibc,
+	})
+}
+
+func (t *astriaLedger) addEntry(entry *ledgerEntry) {
+	// deposits and withdrawals outside of a block, e.g. of simulated calls, are not
+	// part of the ledger
+	if t.block == nil {
+		return
+	}
+	entry.BlockNumber = t.block.NumberU64()
+	entry.BlockHash = t.block.Hash()
+	entry.TxHash = t.txHash
+	t.entries = append(t.entries, entry)
+}
+
+func (t *astriaLedger) OnBlockEnd(err error) {
+	defer func() {
+		t.block = nil
+		t.entries = t.entries[:0]
+	}()
+	if err != nil || len(t.entries) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, entry := range t.entries {
+		if err := t.encoder.Encode(entry); err != nil {
+			log.Error("Failed to write astria ledger entry", "block", entry.BlockNumber, "tx", entry.TxHash, "err", err)
+			return
+		}
+	}
+}
+
+func (t *astriaLedger) OnClose() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.file.Close(); err != nil {
+		log.Error("Failed to close astria ledger", "err", err)
+	}
+}
@@ -0,0 +1,87 @@
+package live
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestAstriaLedger(t *testing.T) {
+	var (
+		path      = filepath.Join(t.TempDir(), "ledger.jsonl")
+		recipient = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		token     = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		tx        = types.NewTx(&types.DepositTx{Value: big.NewInt(1000), To: &recipient})
+	)
+	cfg, _ := json.Marshal(astriaLedgerTracerConfig{Path: path})
+	hooks, err := newAstriaLedgerTracer(cfg)
+	if err != nil {
+		t.Fatalf("failed to create tracer: %v", err)
+	}
+
+	// a valid block with a deposit and a withdrawal
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)})
+	hooks.OnBlockStart(tracing.BlockEvent{Block: block})
+	hooks.OnTxStart(&tracing.VMContext{}, tx, common.Address{})
+	hooks.OnAstriaDeposit(&tracing.AstriaDeposit{
+		BridgeAddress:          "astria1bridge",
+		AssetDenom:             "nria",
+		Recipient:              recipient,
+		Amount:                 big.NewInt(1000),
+		SourceTransactionId:    "0x1234",
+		SourceTransactionIndex: 1,
+	})
+	hooks.OnAstriaWithdrawal(&tracing.AstriaWithdrawal{
+		Contract:                token,
+		Sender:                  recipient,
+		Amount:                  big.NewInt(500),
+		DestinationChainAddress: "astria1destination",
+	})
+	hooks.OnBlockEnd(nil)
+
+	// an invalid block is left out of the ledger
+	hooks.OnBlockStart(tracing.BlockEvent{Block: types.NewBlockWithHeader(&types.Header{Number: big.NewInt(2)})})
+	hooks.OnTxStart(&tracing.VMContext{}, tx, common.Address{})
+	hooks.OnAstriaDeposit(&tracing.AstriaDeposit{Recipient: recipient, Amount: big.NewInt(1000)})
+	hooks.OnBlockEnd(errors.New("invalid block"))
+	hooks.OnClose()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open ledger: %v", err)
+	}
+	defer file.Close()
+	var entries []ledgerEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry ledgerEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid ledger line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 ledger entries, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		if entry.BlockNumber != 1 || entry.BlockHash != block.Hash() || entry.TxHash != tx.Hash() {
+			t.Errorf("entry %d: unexpected location %d %x %x", i, entry.BlockNumber, entry.BlockHash, entry.TxHash)
+		}
+	}
+	if deposit := entries[0]; deposit.Kind != ledgerDeposit || *deposit.Recipient != recipient || deposit.Amount.ToInt().Int64() != 1000 ||
+		deposit.BridgeAddress != "astria1bridge" || deposit.SourceTransactionId != "0x1234" || uint64(*deposit.SourceTransactionIndex) != 1 {
+		t.Errorf("unexpected deposit entry %+v", deposit)
+	}
+	if withdrawal := entries[1]; withdrawal.Kind != ledgerWithdrawal || *withdrawal.Sender != recipient || *withdrawal.Asset != token ||
+		withdrawal.Amount.ToInt().Int64() != 500 || withdrawal.DestinationChainAddress != "astria1destination" {
+		t.Errorf("unexpected withdrawal entry %+v", withdrawal)
+	}
+}
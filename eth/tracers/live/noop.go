@@ -41,6 +41,9 @@ func newNoopTracer(_ json.RawMessage) (*tracing.Hooks, error) {
 		OnCodeChange:     t.OnCodeChange,
 		OnStorageChange:  t.OnStorageChange,
 		OnLog:            t.OnLog,
+
+		OnAstriaDeposit:    t.OnAstriaDeposit,
+		OnAstriaWithdrawal: t.OnAstriaWithdrawal,
 	}, nil
 }
 
@@ -94,3 +97,9 @@ func (t *noop) OnLog(l *types.Log) {
 
 func (t *noop) OnGasChange(old, new uint64, reason tracing.GasChangeReason) {
 }
+
+func (t *noop) OnAstriaDeposit(deposit *tracing.AstriaDeposit) {
+}
+
+func (t *noop) OnAstriaWithdrawal(withdrawal *tracing.AstriaWithdrawal) {
+}
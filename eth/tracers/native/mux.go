@@ -71,6 +71,9 @@ func newMuxTracer(ctx *tracers.Context, cfg json.RawMessage) (*tracers.Tracer, e
 			OnCodeChange:    t.OnCodeChange,
 			OnStorageChange: t.OnStorageChange,
 			OnLog:           t.OnLog,
+
+			OnAstriaDeposit:    t.OnAstriaDeposit,
+			OnAstriaWithdrawal: t.OnAstriaWithdrawal,
 		},
 		GetResult: t.GetResult,
 		Stop:      t.Stop,
@@ -173,6 +176,22 @@ func (t *muxTracer) OnLog(log *types.Log) {
 	}
 }
 
+func (t *muxTracer) OnAstriaDeposit(deposit *tracing.AstriaDeposit) {
+	for _, t := range t.tracers {
+		if t.OnAstriaDeposit != nil {
+			t.OnAstriaDeposit(deposit)
+		}
+	}
+}
+
+func (t *muxTracer) OnAstriaWithdrawal(withdrawal *tracing.AstriaWithdrawal) {
+	for _, t := range t.tracers {
+		if t.OnAstriaWithdrawal != nil {
+			t.OnAstriaWithdrawal(withdrawal)
+		}
+	}
+}
+
 // GetResult returns an empty json object.
 func (t *muxTracer) GetResult() (json.RawMessage, error) {
 	resObject := make(map[string]json.RawMessage)
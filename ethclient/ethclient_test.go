@@ -25,6 +25,7 @@ import (
 	"testing"
 	"time"
 
+	primitivev1 "buf.build/gen/go/astria/primitives/protocolbuffers/go/astria/primitive/v1"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
@@ -209,6 +210,15 @@ var testTx2 = types.MustSignNewTx(testKey, types.LatestSigner(genesis.Config), &
 	To:       &common.Address{2},
 })
 
+var testDepositTx = types.NewTx(&types.DepositTx{
+	From:                   common.Address{4},
+	Value:                  big.NewInt(100),
+	Gas:                    params.TxGas,
+	To:                     &common.Address{3},
+	SourceTransactionId:    primitivev1.TransactionId{Inner: "5d2ad6a0c1a2e8ee5b8a8f4d3d0d3c2bbbd3b6c2a8c1e6d1e4d2c0a7c1f3f0a9"},
+	SourceTransactionIndex: 1,
+})
+
 func newTestBackend(t *testing.T) (*node.Node, []*types.Block) {
 	// Generate test chain.
 	blocks := generateTestChain()
@@ -249,6 +259,7 @@ func generateTestChain() []*types.Block {
 			// Test transactions are included in block #2.
 			g.AddTx(testTx1)
 			g.AddTx(testTx2)
+			g.AddTx(testDepositTx)
 		}
 	}
 	_, blocks, _ := core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), 2, generate)
@@ -294,6 +305,9 @@ func TestEthClient(t *testing.T) {
 		"TransactionSender": {
 			func(t *testing.T) { testTransactionSender(t, client) },
 		},
+		"DepositTransaction": {
+			func(t *testing.T) { testDepositTransaction(t, client) },
+		},
 	}
 
 	t.Parallel()
@@ -737,6 +751,45 @@ func testTransactionSender(t *testing.T, client *rpc.Client) {
 	}
 }
 
+func testDepositTransaction(t *testing.T, client *rpc.Client) {
+	ec := NewClient(client)
+	ctx := context.Background()
+
+	block2, err := ec.BlockByNumber(ctx, big.NewInt(2))
+	if err != nil {
+		t.Fatal("can't get block 2:", err)
+	}
+	byHash, _, err := ec.TransactionByHash(ctx, testDepositTx.Hash())
+	if err != nil {
+		t.Fatal("can't get tx by hash:", err)
+	}
+	inBlock, err := ec.TransactionInBlock(ctx, block2.Hash(), 2)
+	if err != nil {
+		t.Fatal("can't get tx in block:", err)
+	}
+	for _, tx := range []*types.Transaction{byHash, inBlock, block2.Transactions()[2]} {
+		if tx.Hash() != testDepositTx.Hash() {
+			t.Fatalf("wrong tx hash %v, want %v", tx.Hash(), testDepositTx.Hash())
+		}
+		if tx.From() != testDepositTx.From() {
+			t.Errorf("wrong deposit sender %v, want %v", tx.From(), testDepositTx.From())
+		}
+		if tx.DepositSourceTransactionId() != testDepositTx.DepositSourceTransactionId() {
+			t.Errorf("wrong source transaction id %s, want %s", tx.DepositSourceTransactionId(), testDepositTx.DepositSourceTransactionId())
+		}
+		if tx.DepositSourceTransactionIndex() != testDepositTx.DepositSourceTransactionIndex() {
+			t.Errorf("wrong source transaction index %d, want %d", tx.DepositSourceTransactionIndex(), testDepositTx.DepositSourceTransactionIndex())
+		}
+	}
+	sender, err := ec.TransactionSender(ctx, inBlock, block2.Hash(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sender != testDepositTx.From() {
+		t.Fatal("wrong sender:", sender)
+	}
+}
+
 func sendTransaction(ec *Client) error {
 	chainID, err := ec.ChainID(context.Background())
 	if err != nil {
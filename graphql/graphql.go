@@ -351,6 +351,24 @@ func (t *Transaction) BlobVersionedHashes(ctx context.Context) *[]common.Hash {
 	return &blobHashes
 }
 
+func (t *Transaction) SourceTransactionId(ctx context.Context) *string {
+	tx, _ := t.resolve(ctx)
+	if tx == nil || tx.Type() != types.DepositTxType {
+		return nil
+	}
+	id := tx.DepositSourceTransactionId()
+	return &id
+}
+
+func (t *Transaction) SourceTransactionIndex(ctx context.Context) *hexutil.Uint64 {
+	tx, _ := t.resolve(ctx)
+	if tx == nil || tx.Type() != types.DepositTxType {
+		return nil
+	}
+	index := hexutil.Uint64(tx.DepositSourceTransactionIndex())
+	return &index
+}
+
 func (t *Transaction) EffectiveTip(ctx context.Context) (*hexutil.Big, error) {
 	tx, block := t.resolve(ctx)
 	if tx == nil {
@@ -415,8 +433,14 @@ func (t *Transaction) From(ctx context.Context, args BlockNumberArgs) *Account {
 	if tx == nil {
 		return nil
 	}
-	signer := types.LatestSigner(t.r.backend.ChainConfig())
-	from, _ := types.Sender(signer, tx)
+	var from common.Address
+	if tx.Type() == types.DepositTxType {
+		// deposits are not signed, the sender is the bridge sender set in the genesis
+		from = tx.From()
+	} else {
+		signer := types.LatestSigner(t.r.backend.ChainConfig())
+		from, _ = types.Sender(signer, tx)
+	}
 	return &Account{
 		r:             t.r,
 		address:       from,
@@ -27,6 +27,7 @@ import (
 	"testing"
 	"time"
 
+	primitivev1 "buf.build/gen/go/astria/primitives/protocolbuffers/go/astria/primitive/v1"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/beacon"
@@ -431,6 +432,60 @@ func TestWithdrawals(t *testing.T) {
 	}
 }
 
+func TestGraphQLDepositTransaction(t *testing.T) {
+	var (
+		bridge    = common.HexToAddress("0x00000000000000000000000000000000000b71d9")
+		recipient = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+		config    = *params.AllEthashProtocolChanges
+		genesis   = &core.Genesis{
+			Config:     &config,
+			GasLimit:   11500000,
+			Difficulty: common.Big1,
+			Alloc:      types.GenesisAlloc{},
+		}
+		stack = createNode(t)
+		tx    = types.NewTx(&types.DepositTx{
+			From:                   bridge,
+			Value:                  big.NewInt(params.Ether),
+			Gas:                    params.TxGas,
+			To:                     &recipient,
+			SourceTransactionId:    primitivev1.TransactionId{Inner: "5d2ad6a0c1a2e8ee5b8a8f4d3d0d3c2bbbd3b6c2a8c1e6d1e4d2c0a7c1f3f0a9"},
+			SourceTransactionIndex: 3,
+		})
+	)
+	defer stack.Close()
+
+	handler, _ := newGQLService(t, stack, true, genesis, 1, func(i int, gen *core.BlockGen) {
+		gen.AddTx(tx)
+	})
+	// start node
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+
+	for i, tt := range []struct {
+		body string
+		want string
+	}{
+		{
+			body: fmt.Sprintf(`{ transaction(hash: "%s") { type from { address } to { address } value sourceTransactionId sourceTransactionIndex } }`, tx.Hash()),
+			want: `{"transaction":{"type":"0x4","from":{"address":"0x00000000000000000000000000000000000b71d9"},"to":{"address":"0x000000000000000000000000000000000000aaaa"},"value":"0xde0b6b3a7640000","sourceTransactionId":"5d2ad6a0c1a2e8ee5b8a8f4d3d0d3c2bbbd3b6c2a8c1e6d1e4d2c0a7c1f3f0a9","sourceTransactionIndex":"0x3"}}`,
+		},
+	} {
+		res := handler.Schema.Exec(context.Background(), tt.body, "", map[string]interface{}{})
+		if res.Errors != nil {
+			t.Fatalf("failed to execute query for testcase #%d: %v", i, res.Errors)
+		}
+		have, err := json.Marshal(res.Data)
+		if err != nil {
+			t.Fatalf("failed to encode graphql response for testcase #%d: %s", i, err)
+		}
+		if string(have) != tt.want {
+			t.Errorf("response unmatch for testcase #%d.\nhave:\n%s\nwant:\n%s", i, have, tt.want)
+		}
+	}
+}
+
 func createNode(t *testing.T) *node.Node {
 	stack, err := node.New(&node.Config{
 		HTTPHost:     "127.0.0.1",
@@ -170,6 +170,12 @@ const schema string = `
         rawReceipt: Bytes!
         # BlobVersionedHashes is a set of hash outputs from the blobs in the transaction.
         blobVersionedHashes: [Bytes32!]
+        # SourceTransactionId is the hash of the sequencer transaction containing the
+        # source action of an Astria deposit transaction. It is null for other transactions.
+        sourceTransactionId: String
+        # SourceTransactionIndex is the index of the source action of an Astria deposit
+        # transaction within its sequencer transaction. It is null for other transactions.
+        sourceTransactionIndex: Long
     }
 
     # BlockFilterCriteria encapsulates log filter criteria for a filter applied
@@ -0,0 +1,105 @@
+package composer
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	primitivev1 "buf.build/gen/go/astria/primitives/protocolbuffers/go/astria/primitive/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	// collectorServiceName is the full name of the gRPC collector service of the composer.
+	collectorServiceName = "astria.composer.v1.GrpcCollectorService"
+	// submitRollupTransactionMethod is the full name of the method submitting a rollup
+	// transaction to the composer.
+	submitRollupTransactionMethod = "/" + collectorServiceName + "/SubmitRollupTransaction"
+)
+
+// The composer apis are not published as generated go code, so the messages of the
+// collector service are described here and built dynamically.
+var submitRollupTransactionRequestDesc, submitRollupTransactionResponseDesc = collectorMessageDescriptors()
+
+func collectorMessageDescriptors() (protoreflect.MessageDescriptor, protoreflect.MessageDescriptor) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("astria/composer/v1/grpc_collector.proto"),
+		Package:    proto.String("astria.composer.v1"),
+		Dependency: []string{primitivev1.File_astria_primitive_v1_types_proto.Path()},
+		Syntax:     proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("SubmitRollupTransactionRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("rollup_id"),
+						JsonName: proto.String("rollupId"),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".astria.primitive.v1.RollupId"),
+					},
+					{
+						Name:     proto.String("data"),
+						JsonName: proto.String("data"),
+						Number:   proto.Int32(2),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum(),
+					},
+				},
+			},
+			{
+				Name: proto.String("SubmitRollupTransactionResponse"),
+			},
+		},
+	}
+	fd, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	if err != nil {
+		panic(fmt.Sprintf("invalid composer collector descriptor: %v", err))
+	}
+	return fd.Messages().ByName("SubmitRollupTransactionRequest"), fd.Messages().ByName("SubmitRollupTransactionResponse")
+}
+
+// newSubmitRollupTransactionRequest builds the request submitting the data to the rollup.
+func newSubmitRollupTransactionRequest(rollupId []byte, data []byte) *dynamicpb.Message {
+	req := dynamicpb.NewMessage(submitRollupTransactionRequestDesc)
+	fields := submitRollupTransactionRequestDesc.Fields()
+	req.Set(fields.ByName("rollup_id"), protoreflect.ValueOfMessage((&primitivev1.RollupId{Inner: rollupId}).ProtoReflect()))
+	req.Set(fields.ByName("data"), protoreflect.ValueOfBytes(data))
+	return req
+}
+
+// Client submits rollup transactions to the gRPC collector service of a composer.
+type Client struct {
+	conn     *grpc.ClientConn
+	rollupId []byte
+}
+
+// NewClient creates a client of the composer at the given endpoint, submitting
+// transactions to the rollup of the given name.
+func NewClient(endpoint string, rollupName string) (*Client, error) {
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create composer client: %w", err)
+	}
+	rollupId := sha256.Sum256([]byte(rollupName))
+	return &Client{conn: conn, rollupId: rollupId[:]}, nil
+}
+
+// SubmitRollupTransaction submits the encoded transaction to the composer.
+func (c *Client) SubmitRollupTransaction(ctx context.Context, data []byte) error {
+	resp := dynamicpb.NewMessage(submitRollupTransactionResponseDesc)
+	return c.conn.Invoke(ctx, submitRollupTransactionMethod, newSubmitRollupTransactionRequest(c.rollupId, data), resp)
+}
+
+// Close closes the connection to the composer.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
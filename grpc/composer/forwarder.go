@@ -0,0 +1,232 @@
+package composer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// txChanSize is the size of channel listening to NewTxsEvent.
+	txChanSize = 4096
+	// seenCacheSize is the number of transaction hashes remembered to not forward a
+	// transaction announced by the pool more than once.
+	seenCacheSize = 32768
+	// maxPendingTxs is the maximum number of transactions waiting for the batch being
+	// submitted to the composer. Further transactions are dropped until it is submitted.
+	maxPendingTxs = 4096
+	// submitTimeout is the maximum time a single submission to the composer may take.
+	submitTimeout = 5 * time.Second
+)
+
+var (
+	forwardedTxCount = metrics.GetOrRegisterCounter("astria/composer/forwarded_txs", nil)
+	retriedTxCount   = metrics.GetOrRegisterCounter("astria/composer/retried_txs", nil)
+	failedTxCount    = metrics.GetOrRegisterCounter("astria/composer/failed_txs", nil)
+	droppedTxCount   = metrics.GetOrRegisterCounter("astria/composer/dropped_txs", nil)
+)
+
+// Config are the settings of the forwarding of transactions to a composer.
+type Config struct {
+	Endpoint      string        // gRPC endpoint of the composer
+	BatchSize     int           // Maximum number of transactions submitted in one batch
+	FlushInterval time.Duration // Maximum time a transaction waits for its batch to fill up
+	MaxRetries    int           // Number of times a failed submission is retried
+	RetryBackoff  time.Duration // Delay before the first retry, doubled on every further retry
+}
+
+// DefaultConfig contains the default settings of the forwarding of transactions.
+var DefaultConfig = Config{
+	BatchSize:     64,
+	FlushInterval: 100 * time.Millisecond,
+	MaxRetries:    3,
+	RetryBackoff:  200 * time.Millisecond,
+}
+
+// TxPool is the transaction pool the forwarded transactions are received from.
+type TxPool interface {
+	SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription
+}
+
+// Forwarder submits the new transactions of the pool to a composer, which bundles them
+// into sequencer transactions, so that a node does not need a sidecar scraping its
+// mempool for the transactions to be sequenced.
+type Forwarder struct {
+	config Config
+	pool   TxPool
+	client *Client
+
+	seen    *lru.Cache[common.Hash, struct{}] // Transactions forwarded or being forwarded
+	batches chan types.Transactions           // Batches handed to the submission loop
+
+	sub  event.Subscription
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewForwarder creates a forwarder of the transactions of the pool to the composer,
+// submitting them to the rollup of the given name.
+func NewForwarder(config Config, rollupName string, pool TxPool) (*Forwarder, error) {
+	if config.Endpoint == "" {
+		return nil, errors.New("composer endpoint not set")
+	}
+	if rollupName == "" {
+		return nil, errors.New("rollup name not set")
+	}
+	if config.BatchSize < 1 {
+		config.BatchSize = DefaultConfig.BatchSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = DefaultConfig.FlushInterval
+	}
+	client, err := NewClient(config.Endpoint, rollupName)
+	if err != nil {
+		return nil, err
+	}
+	return &Forwarder{
+		config:  config,
+		pool:    pool,
+		client:  client,
+		seen:    lru.NewCache[common.Hash, struct{}](seenCacheSize),
+		batches: make(chan types.Transactions),
+		quit:    make(chan struct{}),
+	}, nil
+}
+
+// Start implements node.Lifecycle, subscribing to the new transactions of the pool.
+func (f *Forwarder) Start() error {
+	txsCh := make(chan core.NewTxsEvent, txChanSize)
+	f.sub = f.pool.SubscribeTransactions(txsCh, false)
+
+	f.wg.Add(2)
+	go f.loop(txsCh)
+	go f.submitLoop()
+
+	log.Info("Forwarding transactions to composer", "endpoint", f.config.Endpoint)
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating the forwarding of transactions.
+func (f *Forwarder) Stop() error {
+	f.sub.Unsubscribe()
+	close(f.quit)
+	f.wg.Wait()
+	return f.client.Close()
+}
+
+// loop batches the new transactions of the pool, never blocking the pool on the
+// submission to the composer. Batches are submitted one at a time, so that the
+// transactions of a sender reach the composer in nonce order.
+func (f *Forwarder) loop(txsCh chan core.NewTxsEvent) {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(f.config.FlushInterval)
+	defer ticker.Stop()
+
+	var batch types.Transactions
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		select {
+		case f.batches <- batch:
+			batch = nil
+		default:
+			// The previous batch is still being submitted, keep collecting until it is done
+		}
+	}
+	for {
+		select {
+		case ev := <-txsCh:
+			for _, tx := range ev.Txs {
+				if f.seen.Contains(tx.Hash()) {
+					continue
+				}
+				if len(batch) >= maxPendingTxs {
+					// Not marked as seen, so that it is forwarded if announced again
+					droppedTxCount.Inc(1)
+					log.Debug("Dropped transaction for composer, too many pending", "hash", tx.Hash())
+					continue
+				}
+				f.seen.Add(tx.Hash(), struct{}{})
+				batch = append(batch, tx)
+			}
+			if len(batch) >= f.config.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case err := <-f.sub.Err():
+			if err != nil {
+				log.Error("Transaction pool subscription failed", "err", err)
+			}
+			return
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// submitLoop submits the batches handed over by loop to the composer, in order.
+func (f *Forwarder) submitLoop() {
+	defer f.wg.Done()
+
+	for {
+		select {
+		case batch := <-f.batches:
+			for _, tx := range batch {
+				if !f.submit(tx) {
+					return
+				}
+			}
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// submit submits the transaction to the composer, retrying failed submissions. It
+// returns false if the forwarder is stopped while waiting to retry.
+func (f *Forwarder) submit(tx *types.Transaction) bool {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		log.Error("Failed to encode transaction for composer", "hash", tx.Hash(), "err", err)
+		failedTxCount.Inc(1)
+		return true
+	}
+	backoff := f.config.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), submitTimeout)
+		err := f.client.SubmitRollupTransaction(ctx, data)
+		cancel()
+		if err == nil {
+			forwardedTxCount.Inc(1)
+			log.Trace("Forwarded transaction to composer", "hash", tx.Hash())
+			return true
+		}
+		if attempt >= f.config.MaxRetries {
+			// Forget the transaction, so that it is forwarded again if announced again
+			f.seen.Remove(tx.Hash())
+			failedTxCount.Inc(1)
+			log.Warn("Failed to forward transaction to composer", "hash", tx.Hash(), "attempts", attempt+1, "err", err)
+			return true
+		}
+		retriedTxCount.Inc(1)
+		log.Debug("Retrying to forward transaction to composer", "hash", tx.Hash(), "attempt", attempt+1, "err", err)
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-f.quit:
+			return false
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package composer
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+type testTxPool struct {
+	feed event.Feed
+}
+
+func (p *testTxPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription {
+	return p.feed.Subscribe(ch)
+}
+
+func testTransactions(t *testing.T, n int) types.Transactions {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := types.LatestSigner(params.TestChainConfig)
+	txs := make(types.Transactions, n)
+	for i := range txs {
+		txs[i] = types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &common.Address{},
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(params.InitialBaseFee),
+		})
+	}
+	return txs
+}
+
+func startForwarder(t *testing.T, config Config) (*MockComposer, *testTxPool, *Forwarder) {
+	composer, err := NewMockComposer()
+	require.NoError(t, err)
+	t.Cleanup(composer.Stop)
+
+	pool := new(testTxPool)
+	config.Endpoint = composer.Endpoint()
+	forwarder, err := NewForwarder(config, "test-rollup", pool)
+	require.NoError(t, err)
+	require.NoError(t, forwarder.Start())
+	t.Cleanup(func() { forwarder.Stop() })
+	return composer, pool, forwarder
+}
+
+func TestForwarderSubmitsTransactions(t *testing.T) {
+	config := DefaultConfig
+	config.BatchSize = 2
+	composer, pool, _ := startForwarder(t, config)
+
+	txs := testTransactions(t, 3)
+	pool.feed.Send(core.NewTxsEvent{Txs: txs[:2]})
+	// announced transactions are only forwarded once
+	pool.feed.Send(core.NewTxsEvent{Txs: txs})
+
+	require.Eventually(t, func() bool { return len(composer.Transactions()) == len(txs) }, time.Second, 10*time.Millisecond)
+	for i, data := range composer.Transactions() {
+		want, err := txs[i].MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, want, data, "unexpected transaction %d", i)
+	}
+	rollupId := sha256.Sum256([]byte("test-rollup"))
+	for _, id := range composer.RollupIds() {
+		require.Equal(t, rollupId[:], id)
+	}
+}
+
+func TestForwarderRetriesFailedSubmissions(t *testing.T) {
+	config := DefaultConfig
+	config.MaxRetries = 2
+	config.RetryBackoff = time.Millisecond
+	composer, pool, _ := startForwarder(t, config)
+
+	composer.FailNext(2)
+	txs := testTransactions(t, 1)
+	pool.feed.Send(core.NewTxsEvent{Txs: txs})
+	require.Eventually(t, func() bool { return len(composer.Transactions()) == 1 }, time.Second, 10*time.Millisecond)
+
+	// a transaction failing all retries is forwarded again when announced again
+	composer.FailNext(3)
+	txs = testTransactions(t, 1)
+	pool.feed.Send(core.NewTxsEvent{Txs: txs})
+	time.Sleep(200 * time.Millisecond)
+	require.Len(t, composer.Transactions(), 1)
+
+	pool.feed.Send(core.NewTxsEvent{Txs: txs})
+	require.Eventually(t, func() bool { return len(composer.Transactions()) == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestForwarderKeepsOrderOnRetries(t *testing.T) {
+	config := DefaultConfig
+	config.BatchSize = 1
+	config.FlushInterval = time.Millisecond
+	config.RetryBackoff = 50 * time.Millisecond
+	composer, pool, _ := startForwarder(t, config)
+
+	// the first batch is retried while the following ones are waiting to be submitted
+	composer.FailNext(1)
+	txs := testTransactions(t, 4)
+	for _, tx := range txs {
+		pool.feed.Send(core.NewTxsEvent{Txs: types.Transactions{tx}})
+	}
+	require.Eventually(t, func() bool { return len(composer.Transactions()) == len(txs) }, time.Second, 10*time.Millisecond)
+	for i, data := range composer.Transactions() {
+		want, err := txs[i].MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, want, data, "transaction %d forwarded out of order", i)
+	}
+}
@@ -0,0 +1,113 @@
+package composer
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	primitivev1 "buf.build/gen/go/astria/primitives/protocolbuffers/go/astria/primitive/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// MockComposer serves the gRPC collector service of a composer on a local port and
+// records the submitted transactions, so that forwarding can be tested without a composer.
+type MockComposer struct {
+	server   *grpc.Server
+	listener net.Listener
+
+	mu        sync.Mutex
+	rollupIds [][]byte
+	txs       [][]byte
+	failures  int // Number of the next submissions to fail
+}
+
+// NewMockComposer starts a mock composer listening on a random local port.
+func NewMockComposer() (*MockComposer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	m := &MockComposer{
+		server:   grpc.NewServer(),
+		listener: listener,
+	}
+	m.server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: collectorServiceName,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "SubmitRollupTransaction",
+			Handler: func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				req := dynamicpb.NewMessage(submitRollupTransactionRequestDesc)
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				if err := m.submitRollupTransaction(req); err != nil {
+					return nil, err
+				}
+				return dynamicpb.NewMessage(submitRollupTransactionResponseDesc), nil
+			},
+		}},
+	}, m)
+	go m.server.Serve(listener)
+	return m, nil
+}
+
+func (m *MockComposer) submitRollupTransaction(req *dynamicpb.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.failures > 0 {
+		m.failures--
+		return status.Error(codes.Unavailable, "mock composer failure")
+	}
+	fields := submitRollupTransactionRequestDesc.Fields()
+	rollupId := new(primitivev1.RollupId)
+	raw, err := proto.Marshal(req.Get(fields.ByName("rollup_id")).Message().Interface())
+	if err != nil {
+		return err
+	}
+	if err := proto.Unmarshal(raw, rollupId); err != nil {
+		return err
+	}
+	m.rollupIds = append(m.rollupIds, rollupId.GetInner())
+	m.txs = append(m.txs, req.Get(fields.ByName("data")).Bytes())
+	return nil
+}
+
+// Endpoint returns the address the mock composer is listening on.
+func (m *MockComposer) Endpoint() string {
+	return m.listener.Addr().String()
+}
+
+// FailNext makes the next n submissions to the mock composer fail.
+func (m *MockComposer) FailNext(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.failures = n
+}
+
+// Transactions returns the encoded transactions submitted to the mock composer.
+func (m *MockComposer) Transactions() [][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([][]byte{}, m.txs...)
+}
+
+// RollupIds returns the rollup ids the transactions were submitted to.
+func (m *MockComposer) RollupIds() [][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([][]byte{}, m.rollupIds...)
+}
+
+// Stop stops serving the mock composer.
+func (m *MockComposer) Stop() {
+	m.server.Stop()
+}
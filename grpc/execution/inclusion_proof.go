@@ -0,0 +1,220 @@
+package execution
+
+import (
+	primitivev1 "buf.build/gen/go/astria/primitives/protocolbuffers/go/astria/primitive/v1"
+	sequencerblockv1 "buf.build/gen/go/astria/sequencerblock-apis/protocolbuffers/go/astria/sequencerblock/v1"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/metrics"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// SequencerBlockHeaderMetadataKey is the gRPC metadata key carrying the protobuf encoded
+	// `SequencerBlockHeader` of the sequencer block an `ExecuteBlockRequest` is derived from.
+	SequencerBlockHeaderMetadataKey = "astria-sequencer-block-header-bin"
+	// RollupTransactionsProofMetadataKey is the gRPC metadata key carrying the protobuf encoded
+	// `Proof` that the rollup transactions are included in the rollup transactions root of the
+	// sequencer block header.
+	RollupTransactionsProofMetadataKey = "astria-rollup-transactions-proof-bin"
+	// RollupAbsenceProofMetadataKey is the gRPC metadata key carrying the protobuf encoded
+	// `RollupTransactions`, with their proofs, of the rollups directly before and after the
+	// rollup in the rollup transactions tree. They prove that a sequencer block holds no
+	// rollup data for the rollup.
+	RollupAbsenceProofMetadataKey = "astria-rollup-absence-proof-bin"
+)
+
+var (
+	inclusionProofVerificationFailures = metrics.GetOrRegisterCounter("astria/execution/inclusion_proof_verification_failures", nil)
+)
+
+// sequencerInclusionProof is the sequencer block header an `ExecuteBlockRequest` is derived
+// from, along with the proof that its rollup data is included in the block, or that the
+// block holds no rollup data for the rollup.
+type sequencerInclusionProof struct {
+	header    *sequencerblockv1.SequencerBlockHeader
+	proof     *primitivev1.Proof                     // nil if no rollup transactions proof was sent
+	neighbors []*sequencerblockv1.RollupTransactions // rollups proving the absence of the rollup
+}
+
+// sequencerInclusionProofFromContext reads the sequencer block header and the rollup
+// transactions or absence proof sent along with an `ExecuteBlockRequest`.
+func sequencerInclusionProofFromContext(ctx context.Context) (*sequencerInclusionProof, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	headerValues := md.Get(SequencerBlockHeaderMetadataKey)
+	if len(headerValues) != 1 {
+		return nil, fmt.Errorf("expected exactly one sequencer block header, got %d", len(headerValues))
+	}
+	header := &sequencerblockv1.SequencerBlockHeader{}
+	if err := proto.Unmarshal([]byte(headerValues[0]), header); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sequencer block header: %w", err)
+	}
+	inclusion := &sequencerInclusionProof{header: header}
+
+	proofValues := md.Get(RollupTransactionsProofMetadataKey)
+	if len(proofValues) > 1 {
+		return nil, fmt.Errorf("expected at most one rollup transactions proof, got %d", len(proofValues))
+	}
+	if len(proofValues) == 1 {
+		inclusion.proof = &primitivev1.Proof{}
+		if err := proto.Unmarshal([]byte(proofValues[0]), inclusion.proof); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rollup transactions proof: %w", err)
+		}
+	}
+
+	neighborValues := md.Get(RollupAbsenceProofMetadataKey)
+	if len(neighborValues) > 2 {
+		return nil, fmt.Errorf("expected at most two rollups proving absence, got %d", len(neighborValues))
+	}
+	for i, value := range neighborValues {
+		neighbor := &sequencerblockv1.RollupTransactions{}
+		if err := proto.Unmarshal([]byte(value), neighbor); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rollup absence proof %d: %w", i, err)
+		}
+		inclusion.neighbors = append(inclusion.neighbors, neighbor)
+	}
+	return inclusion, nil
+}
+
+// verifyRollupTransactions checks that the rollup data of an `ExecuteBlockRequest` is exactly
+// the rollup's portion of the sequencer block with the given header.
+//
+// The rollup transactions root of the header is the Merkle Tree Hash of one leaf per rollup,
+// ordered by rollup id, which is the rollup id followed by the Merkle Tree Hash of the
+// protobuf encoded rollup data of that rollup. A block without rollup data for this rollup
+// must come with an absence proof instead, see verifyRollupAbsence.
+func verifyRollupTransactions(inclusion *sequencerInclusionProof, rollupId []byte, txs []*sequencerblockv1.RollupData) error {
+	header, proof := inclusion.header, inclusion.proof
+	if len(header.RollupTransactionsRoot) != sha256.Size {
+		return fmt.Errorf("rollup transactions root must be %d bytes, got %d", sha256.Size, len(header.RollupTransactionsRoot))
+	}
+	if proof == nil {
+		if len(txs) == 0 {
+			return verifyRollupAbsence(header.RollupTransactionsRoot, inclusion.neighbors, rollupId)
+		}
+		return errors.New("rollup transactions proof is required for a non empty block")
+	}
+
+	leaves := make([][]byte, 0, len(txs))
+	for i, tx := range txs {
+		encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(tx)
+		if err != nil {
+			return fmt.Errorf("failed to marshal rollup data %d: %w", i, err)
+		}
+		leaves = append(leaves, encoded)
+	}
+	rollupLeaf := append(append([]byte{}, rollupId...), merkleTreeHash(leaves)...)
+
+	if !verifyMerkleInclusion(rollupLeaf, proof, header.RollupTransactionsRoot) {
+		return errors.New("rollup transactions do not match the rollup transactions root of the sequencer block")
+	}
+	return nil
+}
+
+// verifyRollupAbsence checks that the rollup transactions tree with the given root holds no
+// leaf for the rollup. Either the tree is empty, or the neighbors are the leaves directly
+// before and after where the rollup would be: two adjacent leaves enclosing the rollup id,
+// the first leaf if its rollup id is greater, or the last one if its rollup id is smaller.
+func verifyRollupAbsence(root []byte, neighbors []*sequencerblockv1.RollupTransactions, rollupId []byte) error {
+	if len(neighbors) == 0 {
+		if !bytes.Equal(root, merkleTreeHash(nil)) {
+			return errors.New("rollup transactions or absence proof is required for a non empty sequencer block")
+		}
+		return nil
+	}
+	for i, neighbor := range neighbors {
+		id := neighbor.GetRollupId().GetInner()
+		if len(id) != sha256.Size {
+			return fmt.Errorf("rollup id of absence proof %d must be %d bytes, got %d", i, sha256.Size, len(id))
+		}
+		if neighbor.GetProof() == nil {
+			return fmt.Errorf("absence proof %d has no proof", i)
+		}
+		leaf := append(append([]byte{}, id...), merkleTreeHash(neighbor.GetTransactions())...)
+		if !verifyMerkleInclusion(leaf, neighbor.GetProof(), root) {
+			return fmt.Errorf("absence proof %d does not match the rollup transactions root of the sequencer block", i)
+		}
+	}
+
+	first, last := neighbors[0], neighbors[len(neighbors)-1]
+	if len(neighbors) == 2 {
+		if first.GetProof().TreeSize != last.GetProof().TreeSize || first.GetProof().LeafIndex+1 != last.GetProof().LeafIndex {
+			return errors.New("rollups proving absence are not adjacent")
+		}
+		if bytes.Compare(first.GetRollupId().GetInner(), rollupId) >= 0 || bytes.Compare(rollupId, last.GetRollupId().GetInner()) >= 0 {
+			return errors.New("rollups proving absence do not enclose the rollup id")
+		}
+		return nil
+	}
+	switch cmp := bytes.Compare(rollupId, first.GetRollupId().GetInner()); {
+	case cmp < 0 && first.GetProof().LeafIndex == 0:
+		return nil
+	case cmp > 0 && first.GetProof().LeafIndex == first.GetProof().TreeSize-1:
+		return nil
+	}
+	return errors.New("rollup proving absence is not at the edge of the rollup transactions tree")
+}
+
+func merkleLeafHash(leaf []byte) []byte {
+	hash := sha256.Sum256(append([]byte{0x00}, leaf...))
+	return hash[:]
+}
+
+func merkleNodeHash(left, right []byte) []byte {
+	hasher := sha256.New()
+	hasher.Write([]byte{0x01})
+	hasher.Write(left)
+	hasher.Write(right)
+	return hasher.Sum(nil)
+}
+
+// merkleTreeHash computes the RFC 6962 Merkle Tree Hash of the given leaves.
+func merkleTreeHash(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		hash := sha256.Sum256(nil)
+		return hash[:]
+	case 1:
+		return merkleLeafHash(leaves[0])
+	}
+	// split at the largest power of two smaller than the number of leaves
+	split := 1
+	for split*2 < len(leaves) {
+		split *= 2
+	}
+	return merkleNodeHash(merkleTreeHash(leaves[:split]), merkleTreeHash(leaves[split:]))
+}
+
+// verifyMerkleInclusion verifies an RFC 6962 audit path for the given leaf against the root.
+func verifyMerkleInclusion(leaf []byte, proof *primitivev1.Proof, root []byte) bool {
+	if proof.TreeSize == 0 || proof.LeafIndex >= proof.TreeSize || len(proof.AuditPath)%sha256.Size != 0 {
+		return false
+	}
+
+	index, lastIndex := proof.LeafIndex, proof.TreeSize-1
+	hash := merkleLeafHash(leaf)
+	for path := proof.AuditPath; len(path) > 0; path = path[sha256.Size:] {
+		if lastIndex == 0 {
+			return false
+		}
+		sibling := path[:sha256.Size]
+		if index%2 == 1 || index == lastIndex {
+			hash = merkleNodeHash(sibling, hash)
+			// skip the levels where this node has no right sibling
+			for index%2 == 0 && index != 0 {
+				index >>= 1
+				lastIndex >>= 1
+			}
+		} else {
+			hash = merkleNodeHash(hash, sibling)
+		}
+		index >>= 1
+		lastIndex >>= 1
+	}
+	return lastIndex == 0 && bytes.Equal(hash, root)
+}
@@ -0,0 +1,235 @@
+package execution
+
+import (
+	astriaPb "buf.build/gen/go/astria/execution-apis/protocolbuffers/go/astria/execution/v1"
+	primitivev1 "buf.build/gen/go/astria/primitives/protocolbuffers/go/astria/primitive/v1"
+	sequencerblockv1 "buf.build/gen/go/astria/sequencerblock-apis/protocolbuffers/go/astria/sequencerblock/v1"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/grpc/shared"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// merkleAuditPath computes the RFC 6962 audit path of the leaf at the given index.
+func merkleAuditPath(leaves [][]byte, index int) []byte {
+	if len(leaves) <= 1 {
+		return nil
+	}
+	split := 1
+	for split*2 < len(leaves) {
+		split *= 2
+	}
+	if index < split {
+		return append(merkleAuditPath(leaves[:split], index), merkleTreeHash(leaves[split:])...)
+	}
+	return append(merkleAuditPath(leaves[split:], index-split), merkleTreeHash(leaves[:split])...)
+}
+
+func TestVerifyMerkleInclusion(t *testing.T) {
+	for size := 1; size <= 9; size++ {
+		leaves := [][]byte{}
+		for i := 0; i < size; i++ {
+			leaves = append(leaves, []byte(fmt.Sprintf("leaf %d", i)))
+		}
+		root := merkleTreeHash(leaves)
+		for index := 0; index < size; index++ {
+			proof := &primitivev1.Proof{AuditPath: merkleAuditPath(leaves, index), LeafIndex: uint64(index), TreeSize: uint64(size)}
+			require.True(t, verifyMerkleInclusion(leaves[index], proof, root), "valid proof of leaf %d in tree of size %d rejected", index, size)
+			require.False(t, verifyMerkleInclusion([]byte("other leaf"), proof, root), "proof of other leaf accepted")
+
+			proof.LeafIndex = uint64((index + 1) % size)
+			require.Equal(t, size == 1, verifyMerkleInclusion(leaves[index], proof, root), "proof with wrong leaf index accepted")
+		}
+	}
+}
+
+func TestVerifyRollupAbsence(t *testing.T) {
+	rollupId := sha256.Sum256([]byte("test rollup"))
+	lowerId, higherId, highestId := [32]byte{}, [32]byte{}, [32]byte{}
+	for i := range higherId {
+		higherId[i], highestId[i] = 0xfe, 0xff
+	}
+	lowerId[0] = 0x01
+	ids := [][32]byte{lowerId, higherId, highestId}
+
+	leaves := [][]byte{}
+	for i, id := range ids {
+		leaves = append(leaves, append(id[:], merkleTreeHash([][]byte{[]byte(fmt.Sprintf("rollup data %d", i))})...))
+	}
+	root := merkleTreeHash(leaves)
+	neighbor := func(index int) *sequencerblockv1.RollupTransactions {
+		return &sequencerblockv1.RollupTransactions{
+			RollupId:     &primitivev1.RollupId{Inner: ids[index][:]},
+			Transactions: [][]byte{[]byte(fmt.Sprintf("rollup data %d", index))},
+			Proof:        &primitivev1.Proof{AuditPath: merkleAuditPath(leaves, index), LeafIndex: uint64(index), TreeSize: uint64(len(leaves))},
+		}
+	}
+
+	tests := []struct {
+		description string
+		root        []byte
+		rollupId    []byte
+		neighbors   []*sequencerblockv1.RollupTransactions
+		valid       bool
+	}{
+		{"empty tree", merkleTreeHash(nil), rollupId[:], nil, true},
+		{"missing absence proof", root, rollupId[:], nil, false},
+		{"enclosing neighbors", root, rollupId[:], []*sequencerblockv1.RollupTransactions{neighbor(0), neighbor(1)}, true},
+		{"non adjacent neighbors", root, rollupId[:], []*sequencerblockv1.RollupTransactions{neighbor(0), neighbor(2)}, false},
+		{"neighbors not enclosing", root, ids[1][:], []*sequencerblockv1.RollupTransactions{neighbor(0), neighbor(1)}, false},
+		{"first leaf", root, []byte{0x00}, []*sequencerblockv1.RollupTransactions{neighbor(0)}, true},
+		{"last leaf", root, append(highestId[:], 0x00), []*sequencerblockv1.RollupTransactions{neighbor(2)}, true},
+		{"leaf not at the edge", root, rollupId[:], []*sequencerblockv1.RollupTransactions{neighbor(1)}, false},
+		{"tampered neighbor", root, []byte{0x00}, []*sequencerblockv1.RollupTransactions{{
+			RollupId:     neighbor(0).RollupId,
+			Transactions: [][]byte{[]byte("other rollup data")},
+			Proof:        neighbor(0).Proof,
+		}}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := verifyRollupAbsence(test.root, test.neighbors, test.rollupId)
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+// sequencerBlockMetadata returns the header and proof metadata of a sequencer block holding
+// the given rollup data for the rollup, and some rollup data of another rollup.
+func sequencerBlockMetadata(t *testing.T, rollupName string, timestamp *timestamppb.Timestamp, txs []*sequencerblockv1.RollupData) metadata.MD {
+	t.Helper()
+	rollupId := sha256.Sum256([]byte(rollupName))
+	otherRollupId := sha256.Sum256([]byte("other rollup"))
+
+	encodedTxs := [][]byte{}
+	for _, tx := range txs {
+		encoded, err := proto.Marshal(tx)
+		require.NoError(t, err, "failed to marshal rollup data")
+		encodedTxs = append(encodedTxs, encoded)
+	}
+	rollupLeaf := append(rollupId[:], merkleTreeHash(encodedTxs)...)
+	otherRollupLeaf := append(otherRollupId[:], merkleTreeHash([][]byte{[]byte("other rollup data")})...)
+	leaves := [][]byte{otherRollupLeaf, rollupLeaf}
+
+	header, err := proto.Marshal(&sequencerblockv1.SequencerBlockHeader{
+		ChainId:                "test-sequencer",
+		Height:                 100,
+		Time:                   timestamp,
+		RollupTransactionsRoot: merkleTreeHash(leaves),
+	})
+	require.NoError(t, err, "failed to marshal sequencer block header")
+	proof, err := proto.Marshal(&primitivev1.Proof{AuditPath: merkleAuditPath(leaves, 1), LeafIndex: 1, TreeSize: 2})
+	require.NoError(t, err, "failed to marshal proof")
+
+	return metadata.Pairs(SequencerBlockHeaderMetadataKey, string(header), RollupTransactionsProofMetadataKey, string(proof))
+}
+
+func TestExecutionServiceServerV1_ExecuteBlockWithInclusionProof(t *testing.T) {
+	ethservice, sharedServiceContainer, _, _ := shared.SetupSharedService(t, 10)
+	serviceV1 := SetupExecutionService(t, sharedServiceContainer)
+	serviceV1.EnableSequencerInclusionProofVerification()
+
+	_, err := serviceV1.GetGenesisInfo(context.Background(), &astriaPb.GetGenesisInfoRequest{})
+	require.Nil(t, err, "GetGenesisInfo failed")
+	_, err = serviceV1.GetCommitmentState(context.Background(), &astriaPb.GetCommitmentStateRequest{})
+	require.Nil(t, err, "GetCommitmentState failed")
+
+	previousBlock := ethservice.BlockChain().CurrentSafeBlock()
+	stateDb, err := ethservice.BlockChain().StateAt(previousBlock.Root)
+	require.Nil(t, err, "Failed to get state db")
+	latestNonce := stateDb.GetNonce(shared.TestAddr)
+
+	txs := []*sequencerblockv1.RollupData{}
+	for i := 0; i < 3; i++ {
+		unsignedTx := types.NewTransaction(latestNonce+uint64(i), shared.TestToAddress, big.NewInt(1), params.TxGas, big.NewInt(params.InitialBaseFee*2), nil)
+		tx, err := types.SignTx(unsignedTx, types.LatestSigner(ethservice.BlockChain().Config()), shared.TestKey)
+		require.Nil(t, err, "Failed to sign tx")
+		marshalledTx, err := tx.MarshalBinary()
+		require.Nil(t, err, "Failed to marshal tx")
+		txs = append(txs, &sequencerblockv1.RollupData{
+			Value: &sequencerblockv1.RollupData_SequencedData{SequencedData: marshalledTx},
+		})
+	}
+
+	timestamp := &timestamppb.Timestamp{Seconds: int64(previousBlock.Time + 2)}
+	rollupName := ethservice.BlockChain().Config().AstriaRollupName
+	validMetadata := sequencerBlockMetadata(t, rollupName, timestamp, txs)
+
+	tests := []struct {
+		description string
+		md          metadata.MD
+		txs         []*sequencerblockv1.RollupData
+		timestamp   *timestamppb.Timestamp
+	}{
+		{
+			description: "missing sequencer block header",
+			md:          metadata.MD{},
+			txs:         txs,
+			timestamp:   timestamp,
+		},
+		{
+			description: "missing rollup transactions proof",
+			md:          metadata.Pairs(SequencerBlockHeaderMetadataKey, validMetadata.Get(SequencerBlockHeaderMetadataKey)[0]),
+			txs:         txs,
+			timestamp:   timestamp,
+		},
+		{
+			description: "empty block without absence proof",
+			md:          metadata.Pairs(SequencerBlockHeaderMetadataKey, validMetadata.Get(SequencerBlockHeaderMetadataKey)[0]),
+			txs:         nil,
+			timestamp:   timestamp,
+		},
+		{
+			description: "omitted rollup data",
+			md:          validMetadata,
+			txs:         txs[:2],
+			timestamp:   timestamp,
+		},
+		{
+			description: "reordered rollup data",
+			md:          validMetadata,
+			txs:         []*sequencerblockv1.RollupData{txs[1], txs[0], txs[2]},
+			timestamp:   timestamp,
+		},
+		{
+			description: "mismatched timestamp",
+			md:          validMetadata,
+			txs:         txs,
+			timestamp:   &timestamppb.Timestamp{Seconds: timestamp.Seconds + 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			_, err := serviceV1.ExecuteBlock(metadata.NewIncomingContext(context.Background(), test.md), &astriaPb.ExecuteBlockRequest{
+				PrevBlockHash: previousBlock.Hash().Bytes(),
+				Timestamp:     test.timestamp,
+				Transactions:  test.txs,
+			})
+			require.Equal(t, codes.InvalidArgument, status.Code(err), "ExecuteBlock should reject the request")
+		})
+	}
+
+	res, err := serviceV1.ExecuteBlock(metadata.NewIncomingContext(context.Background(), validMetadata), &astriaPb.ExecuteBlockRequest{
+		PrevBlockHash: previousBlock.Hash().Bytes(),
+		Timestamp:     timestamp,
+		Transactions:  txs,
+	})
+	require.Nil(t, err, "ExecuteBlock failed with a valid inclusion proof")
+	require.Equal(t, uint32(previousBlock.Number.Uint64()+1), res.Number, "unexpected block number")
+}
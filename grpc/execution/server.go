@@ -38,6 +38,11 @@ type ExecutionServiceServerV1 struct {
 	astriaGrpc.UnimplementedExecutionServiceServer
 
 	sharedServiceContainer *shared.SharedServiceContainer
+
+	// if set, `ExecuteBlock` requests must carry the sequencer block header and the rollup
+	// transactions proof as gRPC metadata, and are rejected if their rollup data does not
+	// match the header
+	verifySequencerInclusionProofs bool
 }
 
 var (
@@ -57,6 +62,7 @@ var (
 	softCommitmentHeight = metrics.GetOrRegisterGauge("astria/execution/soft_commitment_height", nil)
 	firmCommitmentHeight = metrics.GetOrRegisterGauge("astria/execution/firm_commitment_height", nil)
 	totalExecutedTxCount = metrics.GetOrRegisterCounter("astria/execution/total_executed_tx", nil)
+	excludedTxCount      = metrics.GetOrRegisterCounter("astria/execution/excluded_tx", nil)
 
 	executeBlockTimer          = metrics.GetOrRegisterTimer("astria/execution/execute_block_time", nil)
 	commitmentStateUpdateTimer = metrics.GetOrRegisterTimer("astria/execution/commitment", nil)
@@ -70,6 +76,12 @@ func NewExecutionServiceServerV1(sharedServiceContainer *shared.SharedServiceCon
 	return execServiceServerV1
 }
 
+// EnableSequencerInclusionProofVerification makes `ExecuteBlock` verify the rollup data of
+// each request against the sequencer block it is derived from.
+func (s *ExecutionServiceServerV1) EnableSequencerInclusionProofVerification() {
+	s.verifySequencerInclusionProofs = true
+}
+
 func (s *ExecutionServiceServerV1) GetGenesisInfo(ctx context.Context, req *astriaPb.GetGenesisInfoRequest) (*astriaPb.GenesisInfo, error) {
 	log.Debug("GetGenesisInfo called")
 	getGenesisInfoRequestCount.Inc(1)
@@ -161,15 +173,23 @@ func (s *ExecutionServiceServerV1) ExecuteBlock(ctx context.Context, req *astria
 		return nil, status.Error(codes.PermissionDenied, "Cannot execute block until GetGenesisInfo && GetCommitmentState methods are called")
 	}
 
+	if s.verifySequencerInclusionProofs {
+		if err := s.verifySequencerInclusionProof(ctx, req); err != nil {
+			log.Error("ExecuteBlock called with rollup data not matching the sequencer block", "err", err)
+			inclusionProofVerificationFailures.Inc(1)
+			return nil, status.Error(codes.InvalidArgument, shared.WrapError(err, "failed to verify sequencer inclusion proof").Error())
+		}
+	}
+
 	// Validate block being created has valid previous hash
 	prevHeadHash := common.BytesToHash(req.PrevBlockHash)
-	softHash := s.bc().CurrentSafeBlock().Hash()
-	if prevHeadHash != softHash {
+	softBlock := s.bc().CurrentSafeBlock()
+	if prevHeadHash != softBlock.Hash() {
 		return nil, status.Error(codes.FailedPrecondition, "Block can only be created on top of soft block.")
 	}
 
 	// the height that this block will be at
-	height := s.bc().CurrentBlock().Number.Uint64() + 1
+	height := softBlock.Number.Uint64() + 1
 	blockTimestamp := uint64(req.GetTimestamp().GetSeconds())
 	var sequencerHashRef *common.Hash
 	if s.bc().Config().IsCancun(big.NewInt(int64(height)), blockTimestamp) {
@@ -180,23 +200,20 @@ func (s *ExecutionServiceServerV1) ExecuteBlock(ctx context.Context, req *astria
 		sequencerHashRef = &sequencerHash
 	}
 
-	addressPrefix := s.bc().Config().AstriaSequencerAddressPrefix
-
-	txsToProcess := s.unbundleRollupDataTransactions(req.Transactions, height, prevHeadHash.Bytes())
-
-	// This set of ordered TXs on the TxPool is has been configured to be used by
-	// the Miner when building a payload.
-	s.eth().TxPool().SetAstriaOrdered(txsToProcess)
+	txsToProcess, depositLimits, err := s.unbundleRollupDataTransactions(req.Transactions, height, prevHeadHash.Bytes())
+	if err != nil {
+		log.Error("failed to unbundle rollup data transactions", "err", err)
+		return nil, status.Error(codes.Internal, shared.WrapError(err, "failed to unbundle rollup data transactions").Error())
+	}
 
 	// Build a payload to add to the chain
 	payloadAttributes := &miner.BuildPayloadArgs{
-		Parent:                prevHeadHash,
-		Timestamp:             uint64(req.GetTimestamp().GetSeconds()),
-		Random:                common.Hash{},
-		FeeRecipient:          s.nextFeeRecipient(),
-		OverrideTransactions:  types.Transactions{},
-		IsOptimisticExecution: false,
-		BeaconRoot:            sequencerHashRef,
+		Parent:       prevHeadHash,
+		Timestamp:    uint64(req.GetTimestamp().GetSeconds()),
+		Random:       common.Hash{},
+		FeeRecipient: s.feeRecipient(height),
+		Transactions: txsToProcess,
+		BeaconRoot:   sequencerHashRef,
 	}
 	payload, err := s.eth().Miner().BuildPayload(payloadAttributes)
 	if err != nil {
@@ -211,14 +228,18 @@ func (s *ExecutionServiceServerV1) ExecuteBlock(ctx context.Context, req *astria
 		log.Error("failed to convert executable data to block", err)
 		return nil, status.Error(codes.Internal, shared.WrapError(err, "failed to convert executable data to block").Error())
 	}
-	err = s.bc().InsertBlockWithoutSetHead(block)
+	err = s.sharedServiceContainer.InsertBlockWithoutSetHead(block, depositLimits)
 	if err != nil {
 		log.Error("failed to insert block to chain", "hash", block.Hash(), "prevHash", req.PrevBlockHash, "err", err)
 		return nil, status.Error(codes.Internal, shared.WrapError(err, "failed to insert block to chain").Error())
 	}
 
-	// remove txs from original mempool
-	s.eth().TxPool().ClearAstriaOrdered()
+	// remove the txs which were not included in the block from the mempool
+	excluded := payload.ExcludedTransactions()
+	excludedTxCount.Inc(int64(len(excluded)))
+	if removed := s.eth().TxPool().Remove(excluded); removed > 0 {
+		log.Debug("Removed excluded txs from mempool", "count", removed)
+	}
 
 	res := &astriaPb.Block{
 		Number:          uint32(block.NumberU64()),
@@ -229,24 +250,27 @@ func (s *ExecutionServiceServerV1) ExecuteBlock(ctx context.Context, req *astria
 		},
 	}
 
-	if next, ok := s.bc().Config().AstriaFeeCollectors[res.Number+1]; ok {
-		s.setNextFeeRecipient(next)
-	}
-
-	if address, ok := s.bc().Config().AstriaAuctioneerAddresses[res.Number+1]; ok {
-		if err := shared.ValidateBech32mAddress(address, addressPrefix); err != nil {
-			log.Error("auctioneer address is not a valid bech32 address", "block", res.Number+1, "address", address)
-		}
-
-		s.setAuctioneerAddress(address)
-	}
-
-	log.Info("ExecuteBlock completed", "block_num", res.Number, "timestamp", res.Timestamp)
+	gasUsage := core.NewAstriaGasUsage(s.bc().Config(), block.Header(), block.Transactions(), s.bc().GetReceiptsByHash(block.Hash()))
+	log.Info("ExecuteBlock completed", "block_num", res.Number, "timestamp", res.Timestamp, "depositGasUsed", gasUsage.DepositGasUsed, "userGasRemaining", gasUsage.UserGasRemaining)
 	totalExecutedTxCount.Inc(int64(len(block.Transactions())))
 	executeBlockSuccessCount.Inc(1)
 	return res, nil
 }
 
+// verifySequencerInclusionProof checks the rollup data and timestamp of the request against
+// the sequencer block header sent along with it.
+func (s *ExecutionServiceServerV1) verifySequencerInclusionProof(ctx context.Context, req *astriaPb.ExecuteBlockRequest) error {
+	inclusion, err := sequencerInclusionProofFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if inclusion.header.GetTime().GetSeconds() != req.GetTimestamp().GetSeconds() {
+		return fmt.Errorf("timestamp %d does not match sequencer block timestamp %d", req.GetTimestamp().GetSeconds(), inclusion.header.GetTime().GetSeconds())
+	}
+	rollupId := sha256.Sum256([]byte(s.bc().Config().AstriaRollupName))
+	return verifyRollupTransactions(inclusion, rollupId[:], req.Transactions)
+}
+
 // GetCommitmentState fetches the current CommitmentState of the chain.
 func (s *ExecutionServiceServerV1) GetCommitmentState(ctx context.Context, req *astriaPb.GetCommitmentStateRequest) (*astriaPb.CommitmentState, error) {
 	log.Info("GetCommitmentState called")
@@ -424,22 +448,14 @@ func (s *ExecutionServiceServerV1) blockExecutionLock() *sync.Mutex {
 	return s.sharedServiceContainer.BlockExecutionLock()
 }
 
-func (s *ExecutionServiceServerV1) nextFeeRecipient() common.Address {
-	return s.sharedServiceContainer.NextFeeRecipient()
-}
-
-func (s *ExecutionServiceServerV1) setNextFeeRecipient(feeRecipient common.Address) {
-	s.sharedServiceContainer.SetNextFeeRecipient(feeRecipient)
+func (s *ExecutionServiceServerV1) feeRecipient(height uint64) common.Address {
+	return s.sharedServiceContainer.FeeRecipient(height)
 }
 
 func (s *ExecutionServiceServerV1) syncMethodsCalled() bool {
 	return s.sharedServiceContainer.SyncMethodsCalled()
 }
 
-func (s *ExecutionServiceServerV1) setAuctioneerAddress(auctioneerAddress string) {
-	s.sharedServiceContainer.SetAuctioneerAddress(auctioneerAddress)
-}
-
-func (s *ExecutionServiceServerV1) unbundleRollupDataTransactions(txs []*sequencerblockv1.RollupData, height uint64, prevBlockHash []byte) types.Transactions {
+func (s *ExecutionServiceServerV1) unbundleRollupDataTransactions(txs []*sequencerblockv1.RollupData, height uint64, prevBlockHash []byte) (types.Transactions, *shared.DepositLimits, error) {
 	return s.sharedServiceContainer.UnbundleRollupDataTransactions(txs, height, prevBlockHash)
 }
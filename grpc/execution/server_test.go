@@ -10,6 +10,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/grpc/shared"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
@@ -324,9 +326,6 @@ func TestExecutionServiceServerV1_ExecuteBlock(t *testing.T) {
 			if err == nil {
 				require.NotNil(t, executeBlockRes, "ExecuteBlock response is nil")
 
-				astriaOrdered := ethservice.TxPool().AstriaOrdered()
-				require.Equal(t, 0, astriaOrdered.Len(), "AstriaOrdered should be empty")
-
 				// check if commitment state is not updated
 				commitmentStateAfterExecuteBlock, err := serviceV1.GetCommitmentState(context.Background(), &astriaPb.GetCommitmentStateRequest{})
 				require.Nil(t, err, "GetCommitmentState failed")
@@ -415,10 +414,6 @@ func TestExecutionServiceServerV1_ExecuteBlockAndUpdateCommitment(t *testing.T)
 
 	require.NotNil(t, executeBlockRes, "ExecuteBlock response is nil")
 
-	// check if astria ordered txs are cleared
-	astriaOrdered := ethservice.TxPool().AstriaOrdered()
-	require.Equal(t, 0, astriaOrdered.Len(), "AstriaOrdered should be empty")
-
 	// call update commitment state to set the block we executed as soft and firm
 	updateCommitmentStateReq := &astriaPb.UpdateCommitmentStateRequest{
 		CommitmentState: &astriaPb.CommitmentState{
@@ -537,10 +532,6 @@ func TestExecutionServiceServerV1_ExecuteBlockAndUpdateCommitmentWithInvalidTran
 
 	require.NotNil(t, executeBlockRes, "ExecuteBlock response is nil")
 
-	// check if astria ordered txs are cleared
-	astriaOrdered := ethservice.TxPool().AstriaOrdered()
-	require.Equal(t, 0, astriaOrdered.Len(), "AstriaOrdered should be empty")
-
 	// call update commitment state to set the block we executed as soft and firm
 	updateCommitmentStateReq := &astriaPb.UpdateCommitmentStateRequest{
 		CommitmentState: &astriaPb.CommitmentState{
@@ -587,3 +578,139 @@ func TestExecutionServiceServerV1_ExecuteBlockAndUpdateCommitmentWithInvalidTran
 	celestiaBaseHeight := ethservice.BlockChain().CurrentBaseCelestiaHeight()
 	require.Equal(t, celestiaBaseHeight, updateCommitmentStateRes.BaseCelestiaHeight, "BaseCelestiaHeight should be updated in db")
 }
+
+// Check that nodes with different local pool settings derive the same block from the same sequencer data
+func TestExecutionServiceServerV1_ExecuteBlockIndependentOfPoolConfig(t *testing.T) {
+	genesis, blocks, _, _, _, _ := shared.GenerateMergeChain(10, true)
+
+	startNode := func(configure func(*ethconfig.Config)) (*eth.Ethereum, *ExecutionServiceServerV1) {
+		ethservice := shared.StartEthServiceWithConfig(t, genesis, configure)
+		sharedServiceContainer, err := shared.NewSharedServiceContainer(ethservice)
+		require.Nil(t, err, "can't create shared service")
+		_, err = ethservice.BlockChain().InsertChain(blocks)
+		require.Nil(t, err, "can't insert blocks")
+
+		serviceV1 := SetupExecutionService(t, sharedServiceContainer)
+		_, err = serviceV1.GetGenesisInfo(context.Background(), &astriaPb.GetGenesisInfoRequest{})
+		require.Nil(t, err, "GetGenesisInfo failed")
+		_, err = serviceV1.GetCommitmentState(context.Background(), &astriaPb.GetCommitmentStateRequest{})
+		require.Nil(t, err, "GetCommitmentState failed")
+		return ethservice, serviceV1
+	}
+
+	defaultNode, defaultService := startNode(nil)
+	strictNode, strictService := startNode(func(config *ethconfig.Config) {
+		config.TxPool.PriceLimit = 100 * params.GWei
+		config.TxPool.AccountSlots = 1
+		config.TxPool.GlobalSlots = 1
+		config.Miner.GasPrice = big.NewInt(100 * params.GWei)
+	})
+
+	previousBlock := defaultNode.BlockChain().CurrentSafeBlock()
+	require.Equal(t, previousBlock.Hash(), strictNode.BlockChain().CurrentSafeBlock().Hash(), "nodes should start from the same soft block")
+
+	stateDb, err := defaultNode.BlockChain().StateAt(previousBlock.Root)
+	require.Nil(t, err, "Failed to get state db")
+	latestNonce := stateDb.GetNonce(shared.TestAddr)
+
+	// the txs pay a tip below the price limit of the strict node
+	marshalledTxs := []*sequencerblockv1.RollupData{}
+	for i := 0; i < 5; i++ {
+		unsignedTx := types.NewTransaction(latestNonce+uint64(i), shared.TestToAddress, big.NewInt(1), params.TxGas, big.NewInt(params.InitialBaseFee*2), nil)
+		tx, err := types.SignTx(unsignedTx, types.LatestSigner(defaultNode.BlockChain().Config()), shared.TestKey)
+		require.Nil(t, err, "Failed to sign tx")
+
+		marshalledTx, err := tx.MarshalBinary()
+		require.Nil(t, err, "Failed to marshal tx")
+		marshalledTxs = append(marshalledTxs, &sequencerblockv1.RollupData{
+			Value: &sequencerblockv1.RollupData_SequencedData{SequencedData: marshalledTx},
+		})
+	}
+
+	executeBlockReq := &astriaPb.ExecuteBlockRequest{
+		PrevBlockHash: previousBlock.Hash().Bytes(),
+		Timestamp: &timestamppb.Timestamp{
+			Seconds: int64(previousBlock.Time + 2),
+		},
+		Transactions: marshalledTxs,
+	}
+
+	defaultRes, err := defaultService.ExecuteBlock(context.Background(), executeBlockReq)
+	require.Nil(t, err, "ExecuteBlock failed on default node")
+	strictRes, err := strictService.ExecuteBlock(context.Background(), executeBlockReq)
+	require.Nil(t, err, "ExecuteBlock failed on strict node")
+
+	require.True(t, bytes.Equal(defaultRes.Hash, strictRes.Hash), "nodes derived different blocks")
+	block := strictNode.BlockChain().GetBlockByHash(common.BytesToHash(strictRes.Hash))
+	require.NotNil(t, block, "executed block not found")
+	require.Equal(t, 5, block.Transactions().Len(), "block should have 5 txs")
+}
+
+func TestExecutionServiceServerV1_FeeRecipientAfterRollback(t *testing.T) {
+	ethservice, sharedServiceContainer, _, _ := shared.SetupSharedService(t, 10)
+	serviceV1 := SetupExecutionService(t, sharedServiceContainer)
+
+	_, err := serviceV1.GetGenesisInfo(context.Background(), &astriaPb.GetGenesisInfoRequest{})
+	require.Nil(t, err, "GetGenesisInfo failed")
+	commitmentState, err := serviceV1.GetCommitmentState(context.Background(), &astriaPb.GetCommitmentStateRequest{})
+	require.Nil(t, err, "GetCommitmentState failed")
+
+	config := ethservice.BlockChain().Config()
+	softBlock := ethservice.BlockChain().CurrentSafeBlock()
+	softHeight := uint32(softBlock.Number.Uint64())
+	originalCollectors := config.AstriaFeeCollectors
+	collectors := map[uint32]common.Address{
+		1:              originalCollectors[1],
+		softHeight + 2: common.HexToAddress("0x2000000000000000000000000000000000000002"),
+		softHeight + 3: common.HexToAddress("0x3000000000000000000000000000000000000003"),
+	}
+	config.AstriaFeeCollectors = collectors
+	defer func() {
+		config.AstriaFeeCollectors = originalCollectors
+	}()
+
+	executeEmptyBlock := func(parent *astriaPb.Block, timestampOffset int64) *astriaPb.Block {
+		t.Helper()
+		res, err := serviceV1.ExecuteBlock(context.Background(), &astriaPb.ExecuteBlockRequest{
+			PrevBlockHash: parent.Hash,
+			Timestamp:     &timestamppb.Timestamp{Seconds: parent.Timestamp.Seconds + timestampOffset},
+		})
+		require.Nil(t, err, "ExecuteBlock failed")
+		return res
+	}
+	updateSoft := func(soft *astriaPb.Block) {
+		t.Helper()
+		_, err := serviceV1.UpdateCommitmentState(context.Background(), &astriaPb.UpdateCommitmentStateRequest{
+			CommitmentState: &astriaPb.CommitmentState{
+				Soft:               soft,
+				Firm:               commitmentState.Firm,
+				BaseCelestiaHeight: commitmentState.BaseCelestiaHeight,
+			},
+		})
+		require.Nil(t, err, "UpdateCommitmentState failed")
+	}
+	requireCoinbase := func(block *astriaPb.Block, expected common.Address) {
+		t.Helper()
+		header := ethservice.BlockChain().GetHeaderByHash(common.BytesToHash(block.Hash))
+		require.NotNil(t, header, "executed block not found")
+		require.Equal(t, expected, header.Coinbase, "unexpected fee recipient of block %d", block.Number)
+	}
+
+	// build two blocks on top of the soft block, rotating the fee recipient at each of them
+	block1 := executeEmptyBlock(commitmentState.Soft, 2)
+	requireCoinbase(block1, collectors[1])
+	updateSoft(block1)
+	block2 := executeEmptyBlock(block1, 2)
+	requireCoinbase(block2, collectors[softHeight+2])
+	updateSoft(block2)
+
+	// after rolling back the soft block, the rebuilt blocks use the fee recipient of their height
+	updateSoft(block1)
+	rebuiltBlock2 := executeEmptyBlock(block1, 3)
+	require.NotEqual(t, block2.Hash, rebuiltBlock2.Hash, "rebuilt block should differ")
+	requireCoinbase(rebuiltBlock2, collectors[softHeight+2])
+
+	updateSoft(commitmentState.Soft)
+	rebuiltBlock1 := executeEmptyBlock(commitmentState.Soft, 3)
+	requireCoinbase(rebuiltBlock1, collectors[1])
+}
@@ -63,46 +63,21 @@ func (o *AuctionServiceV1Alpha1) GetBidStream(_ *auctionPb.GetBidStreamRequest,
 	pendingTxEvent := o.eth().TxPool().SubscribeTransactions(pendingTxEventCh, false)
 	defer pendingTxEvent.Unsubscribe()
 
+	// txs retained in the mempool across an optimistic head are bid for again in the next auction
+	mempoolClearingEventCh := make(chan core.NewMempoolCleared)
+	mempoolClearingEvent := o.eth().TxPool().SubscribeMempoolClearance(mempoolClearingEventCh)
+	defer mempoolClearingEvent.Unsubscribe()
+
 	for {
 		select {
 		case pendingTxs := <-pendingTxEventCh:
-			// get the optimistic block
-			// this is an in-memory read, so there shouldn't be a lot of concerns on speed
-			optimisticBlock := o.eth().BlockChain().CurrentOptimisticBlock()
-
-			for _, pendingTx := range pendingTxs.Txs {
-				bid := auctionPb.Bid{}
-
-				totalCost := big.NewInt(0)
-				effectiveTip, err := pendingTx.EffectiveGasTip(optimisticBlock.BaseFee)
-				// don't throw an error but we should avoid streaming this bid
-				if err != nil {
-					txsTipTooLow.Inc(1)
-					log.Debug("effective tip is too low", "effectiveTip", effectiveTip.String())
-					continue
-				}
-				totalCost = totalCost.Mul(effectiveTip, big.NewInt(int64(pendingTx.Gas())))
-
-				marshalledTxs := [][]byte{}
-				marshalledTx, err := pendingTx.MarshalBinary()
-				if err != nil {
-					log.Error("error marshalling tx", "err", err)
-					return status.Errorf(codes.Internal, shared.WrapError(err, "error marshalling tx").Error())
-				}
-				marshalledTxs = append(marshalledTxs, marshalledTx)
-
-				bid.Fee = totalCost.Uint64()
-				bid.Transactions = marshalledTxs
-				bid.SequencerParentBlockHash = *o.currentAuctionBlock.Load()
-				bid.RollupParentBlockHash = optimisticBlock.Hash().Bytes()
-
-				txsStreamedCount.Inc(1)
-				log.Debug("streaming bid", "tx", pendingTx.Hash(), "tip", bid.Fee, "parent_block_hash", common.BytesToHash(bid.GetRollupParentBlockHash()).String(), "sequencer_block_hash", common.BytesToHash(bid.GetSequencerParentBlockHash()).String())
-				err = stream.Send(&auctionPb.GetBidStreamResponse{Bid: &bid})
-				if err != nil {
-					log.Error("error sending bid over stream", "err", err)
-					return status.Error(codes.Internal, shared.WrapError(err, "error sending bid over stream").Error())
-				}
+			if err := o.streamBids(stream, pendingTxs.Txs); err != nil {
+				return err
+			}
+
+		case event := <-mempoolClearingEventCh:
+			if err := o.streamBids(stream, event.Retained); err != nil {
+				return err
 			}
 
 		case err := <-pendingTxEvent.Err():
@@ -114,6 +89,15 @@ func (o *AuctionServiceV1Alpha1) GetBidStream(_ *auctionPb.GetBidStreamRequest,
 				return status.Error(codes.Internal, "tx pool subscription closed")
 			}
 
+		case err := <-mempoolClearingEvent.Err():
+			if err != nil {
+				log.Error("error waiting for mempool clearing event", "err", err)
+				return status.Error(codes.Internal, shared.WrapError(err, "error waiting for mempool clearing event").Error())
+			} else {
+				log.Debug("tx pool subscription closed")
+				return status.Error(codes.Internal, "tx pool subscription closed")
+			}
+
 		case <-stream.Context().Done():
 			log.Error("stream closed", "err", stream.Context().Err())
 			return stream.Context().Err()
@@ -121,6 +105,49 @@ func (o *AuctionServiceV1Alpha1) GetBidStream(_ *auctionPb.GetBidStreamRequest,
 	}
 }
 
+// streamBids sends a bid for each of the txs against the current optimistic block.
+func (o *AuctionServiceV1Alpha1) streamBids(stream auctionGrpc.AuctionService_GetBidStreamServer, txs []*types.Transaction) error {
+	// get the optimistic block
+	// this is an in-memory read, so there shouldn't be a lot of concerns on speed
+	optimisticBlock := o.eth().BlockChain().CurrentOptimisticBlock()
+
+	for _, pendingTx := range txs {
+		bid := auctionPb.Bid{}
+
+		totalCost := big.NewInt(0)
+		effectiveTip, err := pendingTx.EffectiveGasTip(optimisticBlock.BaseFee)
+		// don't throw an error but we should avoid streaming this bid
+		if err != nil {
+			txsTipTooLow.Inc(1)
+			log.Debug("effective tip is too low", "effectiveTip", effectiveTip.String())
+			continue
+		}
+		totalCost = totalCost.Mul(effectiveTip, big.NewInt(int64(pendingTx.Gas())))
+
+		marshalledTxs := [][]byte{}
+		marshalledTx, err := pendingTx.MarshalBinary()
+		if err != nil {
+			log.Error("error marshalling tx", "err", err)
+			return status.Errorf(codes.Internal, shared.WrapError(err, "error marshalling tx").Error())
+		}
+		marshalledTxs = append(marshalledTxs, marshalledTx)
+
+		bid.Fee = totalCost.Uint64()
+		bid.Transactions = marshalledTxs
+		bid.SequencerParentBlockHash = *o.currentAuctionBlock.Load()
+		bid.RollupParentBlockHash = optimisticBlock.Hash().Bytes()
+
+		txsStreamedCount.Inc(1)
+		log.Debug("streaming bid", "tx", pendingTx.Hash(), "tip", bid.Fee, "parent_block_hash", common.BytesToHash(bid.GetRollupParentBlockHash()).String(), "sequencer_block_hash", common.BytesToHash(bid.GetSequencerParentBlockHash()).String())
+		err = stream.Send(&auctionPb.GetBidStreamResponse{Bid: &bid})
+		if err != nil {
+			log.Error("error sending bid over stream", "err", err)
+			return status.Error(codes.Internal, shared.WrapError(err, "error sending bid over stream").Error())
+		}
+	}
+	return nil
+}
+
 func (o *AuctionServiceV1Alpha1) ExecuteOptimisticBlockStream(stream optimisticExecutionGrpc.OptimisticExecutionService_ExecuteOptimisticBlockStreamServer) error {
 	log.Debug("ExecuteOptimisticBlockStream called")
 
@@ -157,7 +184,6 @@ func (o *AuctionServiceV1Alpha1) ExecuteOptimisticBlockStream(stream optimisticE
 				log.Error("mempool not cleared after optimistic block execution", "expected_block_hash", optimisticBlockHash.String(), "actual_block_hash", event.NewHead.Hash().String())
 				return status.Error(codes.Internal, "failed to clear mempool after optimistic block execution")
 			}
-			o.currentAuctionBlock.Store(&baseBlock.SequencerBlockHash)
 			executeOptimisticBlockSuccessCount.Inc(1)
 			log.Debug("sending optimistic block response", "block_hash", optimisticBlockHash.String(), "base_block_hash", common.BytesToHash(baseBlock.SequencerBlockHash).String())
 			err = stream.Send(&optimisticExecutionPb.ExecuteOptimisticBlockStreamResponse{
@@ -205,10 +231,8 @@ func (o *AuctionServiceV1Alpha1) ExecuteOptimisticBlock(ctx context.Context, req
 
 	softBlock := o.bc().CurrentSafeBlock()
 
-	nextFeeRecipient := o.nextFeeRecipient()
-
 	// the height that this block will be at
-	height := o.bc().CurrentBlock().Number.Uint64() + 1
+	height := softBlock.Number.Uint64() + 1
 	blockTimestamp := uint64(req.GetTimestamp().GetSeconds())
 	var sequencerHashRef *common.Hash
 	if o.bc().Config().IsCancun(big.NewInt(int64(height)), blockTimestamp) {
@@ -219,17 +243,20 @@ func (o *AuctionServiceV1Alpha1) ExecuteOptimisticBlock(ctx context.Context, req
 		sequencerHashRef = &sequencerHash
 	}
 
-	txsToProcess := o.unbundleRollupDataTransactions(req.Transactions, height, softBlock.Hash().Bytes())
+	txsToProcess, depositLimits, err := o.unbundleRollupDataTransactions(req.Transactions, height, softBlock.Hash().Bytes())
+	if err != nil {
+		log.Error("failed to unbundle rollup data transactions", "err", err)
+		return nil, status.Error(codes.Internal, shared.WrapError(err, "failed to unbundle rollup data transactions").Error())
+	}
 
 	// Build a payload to add to the chain
 	payloadAttributes := &miner.BuildPayloadArgs{
-		Parent:                softBlock.Hash(),
-		Timestamp:             uint64(req.GetTimestamp().GetSeconds()),
-		Random:                common.Hash{},
-		FeeRecipient:          nextFeeRecipient,
-		OverrideTransactions:  txsToProcess,
-		IsOptimisticExecution: true,
-		BeaconRoot:            sequencerHashRef,
+		Parent:       softBlock.Hash(),
+		Timestamp:    uint64(req.GetTimestamp().GetSeconds()),
+		Random:       common.Hash{},
+		FeeRecipient: o.feeRecipient(height),
+		Transactions: txsToProcess,
+		BeaconRoot:   sequencerHashRef,
 	}
 	payload, err := o.eth().Miner().BuildPayload(payloadAttributes)
 	if err != nil {
@@ -245,7 +272,7 @@ func (o *AuctionServiceV1Alpha1) ExecuteOptimisticBlock(ctx context.Context, req
 
 	// this will insert the optimistic block into the chain and persist its state without
 	// setting it as the HEAD.
-	err = o.bc().InsertBlockWithoutSetHead(block)
+	err = o.sharedServiceContainer.InsertBlockWithoutSetHead(block, depositLimits)
 	if err != nil {
 		log.Error("failed to insert block to chain", "hash", block.Hash(), "prevHash", block.ParentHash(), "err", err)
 		return nil, status.Error(codes.Internal, shared.WrapError(err, "failed to insert block to chain").Error())
@@ -254,7 +281,9 @@ func (o *AuctionServiceV1Alpha1) ExecuteOptimisticBlock(ctx context.Context, req
 	// we store a pointer to the optimistic block in the chain so that we can use it
 	// to retrieve the state of the optimistic block
 	// this method also sends an event which indicates that a new optimistic block has been set
-	// the mempool clearing logic is triggered when this event is received
+	// the mempool clearing logic is triggered when this event is received, so the auction
+	// block is stored before for the txs retained in the mempool to be bid for against it
+	o.currentAuctionBlock.Store(&req.SequencerBlockHash)
 	o.bc().SetOptimistic(block)
 
 	res := &astriaPb.Block{
@@ -281,14 +310,14 @@ func (o *AuctionServiceV1Alpha1) bc() *core.BlockChain {
 	return o.sharedServiceContainer.Bc()
 }
 
-func (o *AuctionServiceV1Alpha1) nextFeeRecipient() common.Address {
-	return o.sharedServiceContainer.NextFeeRecipient()
+func (o *AuctionServiceV1Alpha1) feeRecipient(height uint64) common.Address {
+	return o.sharedServiceContainer.FeeRecipient(height)
 }
 
 func (o *AuctionServiceV1Alpha1) syncMethodsCalled() bool {
 	return o.sharedServiceContainer.SyncMethodsCalled()
 }
 
-func (o *AuctionServiceV1Alpha1) unbundleRollupDataTransactions(txs []*sequencerblockv1.RollupData, height uint64, prevBlockHash []byte) types.Transactions {
+func (o *AuctionServiceV1Alpha1) unbundleRollupDataTransactions(txs []*sequencerblockv1.RollupData, height uint64, prevBlockHash []byte) (types.Transactions, *shared.DepositLimits, error) {
 	return o.sharedServiceContainer.UnbundleRollupDataTransactions(txs, height, prevBlockHash)
 }
@@ -153,9 +153,6 @@ func TestAuctionServiceServerV1Alpha1_ExecuteOptimisticBlock(t *testing.T) {
 			if err == nil {
 				require.NotNil(t, res, "ExecuteOptimisticBlock response is nil")
 
-				astriaOrdered := ethservice.TxPool().AstriaOrdered()
-				require.Equal(t, 0, astriaOrdered.Len(), "AstriaOrdered should be empty")
-
 				// check if commitment state is not updated
 				commitmentStateAfterExecuteBlock, err := executionServiceV1.GetCommitmentState(context.Background(), &astriaPb.GetCommitmentStateRequest{})
 				require.Nil(t, err, "GetCommitmentState failed")
@@ -275,9 +272,6 @@ func TestAuctionServiceServerV1Alpha_StreamBids(t *testing.T) {
 	require.Equal(t, uint64(accumulatedResponse.GetBlock().Number), currentOptimisticBlock.Number.Uint64(), "Optimistic block numbers do not match")
 
 	// assert mempool is cleared
-	astriaOrdered := ethservice.TxPool().AstriaOrdered()
-	require.Equal(t, 0, astriaOrdered.Len(), "AstriaOrdered should be empty")
-
 	pending, queued = ethservice.TxPool().Stats()
 	require.Equal(t, pending, 0, "Mempool should have 0 pending txs")
 	require.Equal(t, queued, 0, "Mempool should have 0 queued txs")
@@ -438,10 +432,75 @@ func TestAuctionServiceServerV1_StreamExecuteOptimisticBlock(t *testing.T) {
 	}
 
 	// ensure mempool is cleared
-	astriaOrdered := ethservice.TxPool().AstriaOrdered()
-	require.Equal(t, 0, astriaOrdered.Len(), "AstriaOrdered should be empty")
-
 	pending, queued = ethservice.TxPool().Stats()
 	require.Equal(t, pending, 0, "Mempool should have 0 pending txs")
 	require.Equal(t, queued, 0, "Mempool should have 0 queued txs")
 }
+
+func TestAuctionServiceServerV1Alpha1_ExecuteOptimisticBlockFeeRecipient(t *testing.T) {
+	ethservice, sharedService, _, _ := shared.SetupSharedService(t, 10)
+	auctionServiceV1Alpha1 := SetupAuctionService(t, sharedService)
+	executionServiceV1 := execution.SetupExecutionService(t, sharedService)
+
+	_, err := executionServiceV1.GetGenesisInfo(context.Background(), &astriaPb.GetGenesisInfoRequest{})
+	require.Nil(t, err, "GetGenesisInfo failed")
+	commitmentState, err := executionServiceV1.GetCommitmentState(context.Background(), &astriaPb.GetCommitmentStateRequest{})
+	require.Nil(t, err, "GetCommitmentState failed")
+
+	// the fee recipient of the next blocks was not active when the shared service was created
+	config := ethservice.BlockChain().Config()
+	softHeight := uint32(ethservice.BlockChain().CurrentSafeBlock().Number.Uint64())
+	originalCollectors := config.AstriaFeeCollectors
+	collectors := map[uint32]common.Address{
+		1:              originalCollectors[1],
+		softHeight + 1: common.HexToAddress("0x1000000000000000000000000000000000000001"),
+		softHeight + 2: common.HexToAddress("0x2000000000000000000000000000000000000002"),
+	}
+	config.AstriaFeeCollectors = collectors
+	defer func() {
+		config.AstriaFeeCollectors = originalCollectors
+	}()
+
+	executeOptimisticBlock := func(parent *astriaPb.Block) *astriaPb.Block {
+		t.Helper()
+		res, err := auctionServiceV1Alpha1.ExecuteOptimisticBlock(context.Background(), &optimisticExecutionPb.BaseBlock{
+			Timestamp:          &timestamppb.Timestamp{Seconds: parent.Timestamp.Seconds + 2},
+			SequencerBlockHash: []byte("test_hash"),
+		})
+		require.Nil(t, err, "ExecuteOptimisticBlock failed")
+		require.Equal(t, parent.Hash, res.ParentBlockHash, "optimistic block should build on the soft block")
+		return res
+	}
+	requireCoinbase := func(block *astriaPb.Block, expected common.Address) {
+		t.Helper()
+		header := ethservice.BlockChain().GetHeaderByHash(common.BytesToHash(block.Hash))
+		require.NotNil(t, header, "optimistic block not found")
+		require.Equal(t, expected, header.Coinbase, "unexpected fee recipient of block %d", block.Number)
+	}
+
+	optimisticBlock := executeOptimisticBlock(commitmentState.Soft)
+	requireCoinbase(optimisticBlock, collectors[softHeight+1])
+
+	// a second optimistic block at the same height uses the same fee recipient
+	optimisticBlock = executeOptimisticBlock(commitmentState.Soft)
+	requireCoinbase(optimisticBlock, collectors[softHeight+1])
+
+	// once the soft block moves, optimistic blocks use the fee recipient of the new height
+	softBlock, err := executionServiceV1.ExecuteBlock(context.Background(), &astriaPb.ExecuteBlockRequest{
+		PrevBlockHash: commitmentState.Soft.Hash,
+		Timestamp:     &timestamppb.Timestamp{Seconds: commitmentState.Soft.Timestamp.Seconds + 2},
+	})
+	require.Nil(t, err, "ExecuteBlock failed")
+	requireCoinbase(softBlock, collectors[softHeight+1])
+	_, err = executionServiceV1.UpdateCommitmentState(context.Background(), &astriaPb.UpdateCommitmentStateRequest{
+		CommitmentState: &astriaPb.CommitmentState{
+			Soft:               softBlock,
+			Firm:               commitmentState.Firm,
+			BaseCelestiaHeight: commitmentState.BaseCelestiaHeight,
+		},
+	})
+	require.Nil(t, err, "UpdateCommitmentState failed")
+
+	optimisticBlock = executeOptimisticBlock(softBlock)
+	requireCoinbase(optimisticBlock, collectors[softHeight+2])
+}
@@ -3,6 +3,7 @@ package shared
 import (
 	auctionv1alpha1 "buf.build/gen/go/astria/execution-apis/protocolbuffers/go/astria/auction/v1alpha1"
 	sequencerblockv1 "buf.build/gen/go/astria/sequencerblock-apis/protocolbuffers/go/astria/sequencerblock/v1"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
@@ -13,7 +14,6 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"google.golang.org/protobuf/proto"
 	"sync"
-	"sync/atomic"
 )
 
 type SharedServiceContainer struct {
@@ -28,14 +28,10 @@ type SharedServiceContainer struct {
 
 	bridgeAddresses     map[string]*params.AstriaBridgeAddressConfig // astria bridge addess to config for that bridge account
 	bridgeAllowedAssets map[string]struct{}                          // a set of allowed asset IDs structs are left empty
+	depositRateLimited  bool                                         // whether any bridge has a deposit rate limit configured
 
-	// auctioneer address is a bech32m address
-	auctioneerAddress atomic.Pointer[string]
-	// this is set to the height at which the first auctioneer address is activated.
-	// before `auctioneerStartHeight` any incoming `Allocations` will be ignored
-	auctioneerStartHeight uint64
-
-	nextFeeRecipient atomic.Pointer[common.Address] // Fee recipient for the next block
+	// decoders of enveloped sequenced data, keyed by the kind byte of the envelope
+	rollupDataDecoders map[RollupDataKind]*rollupDataDecoderEntry
 }
 
 func NewSharedServiceContainer(eth *eth.Ethereum) (*SharedServiceContainer, error) {
@@ -59,6 +55,7 @@ func NewSharedServiceContainer(eth *eth.Ethereum) (*SharedServiceContainer, erro
 
 	bridgeAddresses := make(map[string]*params.AstriaBridgeAddressConfig)
 	bridgeAllowedAssets := make(map[string]struct{})
+	depositRateLimited := false
 	if bc.Config().AstriaBridgeAddressConfigs == nil {
 		log.Warn("bridge addresses not set")
 	} else {
@@ -83,6 +80,10 @@ func NewSharedServiceContainer(eth *eth.Ethereum) (*SharedServiceContainer, erro
 			bridgeCfg := cfg
 			bridgeAddresses[cfg.BridgeAddress] = &bridgeCfg
 			bridgeAllowedAssets[cfg.AssetDenom] = struct{}{}
+			if cfg.RateLimit != nil {
+				depositRateLimited = true
+				log.Info("deposit rate limit set for bridge", "bridgeAddress", cfg.BridgeAddress, "policy", cfg.RateLimit.Policy())
+			}
 			if cfg.Erc20Asset == nil {
 				log.Info("bridge for sequencer native asset initialized", "bridgeAddress", cfg.BridgeAddress, "assetDenom", cfg.AssetDenom)
 			} else {
@@ -91,76 +92,76 @@ func NewSharedServiceContainer(eth *eth.Ethereum) (*SharedServiceContainer, erro
 		}
 	}
 
-	// To decrease compute cost, we identify the next fee recipient at the start
-	// and update it as we execute blocks.
-	nextFeeRecipient := common.Address{}
-	nextBlock := uint32(bc.CurrentBlock().Number.Int64()) + 1
+	// fee recipients and auctioneer addresses are resolved from the config at the height of
+	// each block, so that they do not depend on which blocks were built before.
 	if bc.Config().AstriaFeeCollectors == nil {
 		log.Warn("fee asset collectors not set, assets will be burned")
-	} else {
-		maxHeightCollectorMatch := uint32(0)
-		for height, collector := range bc.Config().AstriaFeeCollectors {
-			if height <= nextBlock && height > maxHeightCollectorMatch {
-				maxHeightCollectorMatch = height
-				nextFeeRecipient = collector
-			}
-		}
 	}
 
-	auctioneerAddress := ""
-	// the height at which the first auctioneer address is activated.
-	// if auctioneer addresses are not set, this height will be set to ^uint64(0) which is the max value of uint64
-	// this will cause all allocations to be ignored until auctioneer address is set
-	auctioneerStartHeight := ^uint64(0)
 	if bc.Config().AstriaAuctioneerAddresses == nil {
 		log.Warn("auctioneer addresses not set. allocations will be ignored until auctioneer address is set")
-	} else {
-		maxHeightCollectorMatch := uint32(0)
-		for height, address := range bc.Config().AstriaAuctioneerAddresses {
-			if height <= nextBlock && height > maxHeightCollectorMatch {
-				maxHeightCollectorMatch = height
-
-				if err := ValidateBech32mAddress(address, bc.Config().AstriaSequencerAddressPrefix); err != nil {
-					return nil, WrapError(err, fmt.Sprintf("auctioneer address %s at height %d is invalid", address, height))
-				}
-				auctioneerAddress = address
-			}
+	}
+	for height, address := range bc.Config().AstriaAuctioneerAddresses {
+		if address == "" {
+			log.Info("auctions disabled", "height", height)
+			continue
 		}
-
-		for height := range bc.Config().AstriaAuctioneerAddresses {
-			if uint64(height) < auctioneerStartHeight {
-				auctioneerStartHeight = uint64(height)
-			}
+		if err := ValidateBech32mAddress(address, bc.Config().AstriaSequencerAddressPrefix); err != nil {
+			return nil, WrapError(err, fmt.Sprintf("auctioneer address %s at height %d is invalid", address, height))
 		}
 	}
 
 	sharedServiceContainer := &SharedServiceContainer{
-		eth:                   eth,
-		bc:                    bc,
-		bridgeAddresses:       bridgeAddresses,
-		bridgeAllowedAssets:   bridgeAllowedAssets,
-		auctioneerStartHeight: auctioneerStartHeight,
+		eth:                 eth,
+		bc:                  bc,
+		bridgeAddresses:     bridgeAddresses,
+		bridgeAllowedAssets: bridgeAllowedAssets,
+		depositRateLimited:  depositRateLimited,
+		rollupDataDecoders:  defaultRollupDataDecoders(),
 	}
 
-	sharedServiceContainer.SetAuctioneerAddress(auctioneerAddress)
-	sharedServiceContainer.SetNextFeeRecipient(nextFeeRecipient)
-
 	return sharedServiceContainer, nil
 }
 
 // `UnbundleRollupDataTransactions` takes in a list of rollup data transactions and returns the corresponding
 // list of Ethereum transactions.
 // If it finds any `Allocation` type, it validates it and places the txs in the `Allocation` at the top of block.
+// From `AstriaRollupDataEnvelopeHeight` on, sequenced data starting with `RollupDataEnvelopePrefix` is decoded
+// by the decoder registered for its kind, and any other sequenced data is decoded as an Ethereum transaction.
+// Before that height, allocations are detected by whether the sequenced data unmarshals into an `Allocation`.
+// Deposits deferred by the bridge rate limits of previous blocks are minted first, followed by the deposits and
+// sequenced txs of this block. The returned `DepositLimits` is the rate limiter state after this block, which
+// must be passed to `InsertBlockWithoutSetHead` along with the block built. It is nil if no rate limit is configured.
+// An error is only returned if the rate limiter state of the parent block cannot be loaded.
 // Note that `UnbundleRollupDataTransactions` does not return any error on an invalid `RollupData`. If we find any invalid
 // `RollupData` we log the error and continue processing the rest of the transactions. We do not want to break control flow
 // for an invalid transaction as we do not want to interrupt block production.
-func (s *SharedServiceContainer) UnbundleRollupDataTransactions(txs []*sequencerblockv1.RollupData, height uint64, prevBlockHash []byte) types.Transactions {
+func (s *SharedServiceContainer) UnbundleRollupDataTransactions(txs []*sequencerblockv1.RollupData, height uint64, prevBlockHash []byte) (types.Transactions, *DepositLimits, error) {
 	processedTxs := types.Transactions{}
 	allocationTxs := types.Transactions{}
 
 	foundAllocation := false
 	allocation := &auctionv1alpha1.Allocation{}
 
+	limiter, err := s.depositLimiter(height, common.BytesToHash(prevBlockHash))
+	if err != nil {
+		return nil, nil, err
+	}
+	if limiter != nil {
+		for _, deposit := range limiter.releaseDeferred() {
+			depositTx, err := validateAndUnmarshalDepositTx(deposit, height, s.BridgeAddresses(), s.BridgeAllowedAssets())
+			if err != nil {
+				log.Error("failed to validate and unmarshal deferred deposit tx", "error", err)
+				continue
+			}
+			processedTxs = append(processedTxs, depositTx)
+		}
+	}
+
+	envelopeEnabled := s.bc.Config().IsAstriaRollupDataEnvelope(height)
+	auctioneerAddresses := s.AuctioneerAddresses(height)
+	decodeCtx := &RollupDataDecodeContext{Height: height, PrevBlockHash: prevBlockHash, Service: s}
+
 	for _, tx := range txs {
 		switch {
 		case tx.GetDeposit() != nil:
@@ -169,9 +170,28 @@ func (s *SharedServiceContainer) UnbundleRollupDataTransactions(txs []*sequencer
 				log.Error("failed to validate and unmarshal deposit tx", "error", err)
 				continue
 			}
+			if limiter != nil && limiter.admit(tx.GetDeposit()) != depositAdmitted {
+				continue
+			}
 			processedTxs = append(processedTxs, depositTx)
-		case !foundAllocation && height >= s.AuctioneerStartHeight() && proto.Unmarshal(tx.GetSequencedData(), allocation) == nil:
-			unmarshalledAllocationTxs, err := unmarshalAllocationTxs(allocation, prevBlockHash, s.AuctioneerAddress(), s.Bc().Config().AstriaSequencerAddressPrefix)
+		case envelopeEnabled && isRollupDataEnvelope(tx.GetSequencedData()):
+			decoded, err := s.decodeRollupDataEnvelope(decodeCtx, tx.GetSequencedData())
+			if err != nil {
+				log.Error("failed to decode rollup data envelope", "error", err)
+				continue
+			}
+			if !decoded.TopOfBlock {
+				processedTxs = append(processedTxs, decoded.Txs...)
+				continue
+			}
+			if foundAllocation {
+				log.Warn("ignoring top of block rollup data, block already has top of block txs")
+				continue
+			}
+			allocationTxs = decoded.Txs
+			foundAllocation = true
+		case !envelopeEnabled && !foundAllocation && len(auctioneerAddresses) > 0 && proto.Unmarshal(tx.GetSequencedData(), allocation) == nil:
+			unmarshalledAllocationTxs, err := unmarshalAllocationTxs(allocation, prevBlockHash, auctioneerAddresses, s.Bc().Config().AstriaSequencerAddressPrefix)
 			if err != nil {
 				log.Error("failed to unmarshall allocation transactions", "error", err)
 				continue
@@ -189,10 +209,72 @@ func (s *SharedServiceContainer) UnbundleRollupDataTransactions(txs []*sequencer
 		}
 	}
 
+	// deposits are executed ahead of the sequenced txs if gas is reserved for them, but
+	// still after the allocation txs which are sold as top of block
+	if s.bc.Config().AstriaDepositGasLimit(height) > 0 {
+		processedTxs = depositsFirst(processedTxs)
+	}
 	// prepend allocation txs to processedTxs
 	processedTxs = append(allocationTxs, processedTxs...)
 
-	return processedTxs
+	var limits *DepositLimits
+	if limiter != nil {
+		limits = limiter.finalize()
+	}
+
+	return processedTxs, limits, nil
+}
+
+// depositLimiter returns a rate limiter starting from the state after the parent block, or nil
+// if no bridge is rate limited. If the parent has no state while a rate limit applied to it, as
+// it was not built by this node, the state is rebuilt from the chain.
+func (s *SharedServiceContainer) depositLimiter(height uint64, parentHash common.Hash) (*depositLimiter, error) {
+	if !s.depositRateLimited {
+		return nil, nil
+	}
+	parent, err := readDepositLimits(s.eth.ChainDb(), parentHash)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil && height > 0 && s.depositRateLimitedAt(height-1) {
+		header := s.bc.GetHeaderByHash(parentHash)
+		if header == nil {
+			return nil, fmt.Errorf("parent block %s not found", parentHash)
+		}
+		log.Warn("deposit limits of parent block not found, rebuilding them from the chain without deferred deposits", "hash", parentHash)
+		if parent, err = rebuildDepositLimits(s.bc, s.bridgeAddresses, header); err != nil {
+			return nil, fmt.Errorf("failed to rebuild deposit limits of parent block %s: %w", parentHash, err)
+		}
+	}
+	if parent == nil {
+		parent = &DepositLimits{Bridges: make(map[string]*bridgeDepositLimits)}
+	}
+	return newDepositLimiter(parent, height, s.bridgeAddresses), nil
+}
+
+// depositRateLimitedAt returns whether the rate limit of any bridge applies to the block at
+// the given height.
+func (s *SharedServiceContainer) depositRateLimitedAt(height uint64) bool {
+	for _, bac := range s.bridgeAddresses {
+		if bac.RateLimit.ActiveAt(height) {
+			return true
+		}
+	}
+	return false
+}
+
+// InsertBlockWithoutSetHead inserts the block built from the transactions returned by
+// `UnbundleRollupDataTransactions` into the chain without setting it as the head, persisting
+// the deposit rate limiter state returned along with them in the same batch as the block.
+func (s *SharedServiceContainer) InsertBlockWithoutSetHead(block *types.Block, limits *DepositLimits) error {
+	if limits == nil {
+		return s.bc.InsertBlockWithoutSetHead(block)
+	}
+	data, err := json.Marshal(limits)
+	if err != nil {
+		return fmt.Errorf("failed to encode deposit limits: %w", err)
+	}
+	return s.bc.InsertAstriaBlockWithoutSetHead(block, data)
 }
 
 func (s *SharedServiceContainer) SyncMethodsCalled() bool {
@@ -231,12 +313,9 @@ func (s *SharedServiceContainer) BlockExecutionLock() *sync.Mutex {
 	return &s.blockExecutionLock
 }
 
-func (s *SharedServiceContainer) NextFeeRecipient() common.Address {
-	return *s.nextFeeRecipient.Load()
-}
-
-func (s *SharedServiceContainer) SetNextFeeRecipient(nextFeeRecipient common.Address) {
-	s.nextFeeRecipient.Store(&nextFeeRecipient)
+// FeeRecipient returns the fee recipient of the block at the given height.
+func (s *SharedServiceContainer) FeeRecipient(height uint64) common.Address {
+	return s.bc.Config().AstriaFeeCollectorAt(height)
 }
 
 func (s *SharedServiceContainer) BridgeAddresses() map[string]*params.AstriaBridgeAddressConfig {
@@ -247,14 +326,8 @@ func (s *SharedServiceContainer) BridgeAllowedAssets() map[string]struct{} {
 	return s.bridgeAllowedAssets
 }
 
-func (s *SharedServiceContainer) AuctioneerStartHeight() uint64 {
-	return s.auctioneerStartHeight
-}
-
-func (s *SharedServiceContainer) AuctioneerAddress() string {
-	return *s.auctioneerAddress.Load()
-}
-
-func (s *SharedServiceContainer) SetAuctioneerAddress(newAddress string) {
-	s.auctioneerAddress.Store(&newAddress)
+// AuctioneerAddresses returns the bech32m addresses of the auctioneers whose allocations
+// are valid at the given height. It is empty if auctions are disabled at that height.
+func (s *SharedServiceContainer) AuctioneerAddresses(height uint64) []string {
+	return s.bc.Config().AstriaAuctioneerAddressesAt(height)
 }
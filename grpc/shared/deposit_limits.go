@@ -0,0 +1,333 @@
+package shared
+
+import (
+	sequencerblockv1 "buf.build/gen/go/astria/sequencerblock-apis/protocolbuffers/go/astria/sequencerblock/v1"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+	"google.golang.org/protobuf/proto"
+	"math/big"
+	"sort"
+)
+
+var (
+	depositsDeferred    = metrics.GetOrRegisterCounter("astria/deposits/deferred", nil)
+	depositsReleased    = metrics.GetOrRegisterCounter("astria/deposits/released", nil)
+	depositsRateLimited = metrics.GetOrRegisterCounter("astria/deposits/rate_limited", nil)
+)
+
+// depositDecision is the outcome of checking a deposit against the rate limit of its bridge.
+type depositDecision int
+
+const (
+	depositAdmitted depositDecision = iota
+	depositDeferred
+	depositRejected
+)
+
+// mintedAtHeight is the amount a bridge minted in the block at the given height.
+type mintedAtHeight struct {
+	Height uint64   `json:"height"`
+	Amount *big.Int `json:"amount"`
+}
+
+// bridgeDepositLimits is the rate limiter state of a single bridge.
+type bridgeDepositLimits struct {
+	// Window holds the amounts minted in the last `WindowBlocks` blocks, oldest first.
+	Window []mintedAtHeight `json:"window,omitempty"`
+	// Deferred holds the marshalled deposits waiting to be minted, in arrival order.
+	Deferred [][]byte `json:"deferred,omitempty"`
+}
+
+// DepositLimits is the state of the deposit rate limiter after a block has been built. It
+// is persisted keyed by the block hash, so that the next block built on top of it starts
+// from the same state on every node regardless of restarts or soft head rollbacks.
+type DepositLimits struct {
+	Bridges map[string]*bridgeDepositLimits `json:"bridges,omitempty"`
+}
+
+// readDepositLimits loads the rate limiter state after the block with the given hash. It
+// returns nil if the block has no state.
+func readDepositLimits(db ethdb.KeyValueReader, hash common.Hash) (*DepositLimits, error) {
+	data := rawdb.ReadAstriaDepositLimits(db, hash)
+	if len(data) == 0 {
+		return nil, nil
+	}
+	limits := &DepositLimits{}
+	if err := json.Unmarshal(data, limits); err != nil {
+		return nil, fmt.Errorf("failed to decode deposit limits of block %s: %w", hash, err)
+	}
+	if limits.Bridges == nil {
+		limits.Bridges = make(map[string]*bridgeDepositLimits)
+	}
+	return limits, nil
+}
+
+// rebuildDepositLimits derives the rate limiter state after the given block from the deposit
+// txs of the blocks in the rate limit windows ending at it, for a block which was not built by
+// this node, e.g. because it was synced from peers. Deferred deposits never made it into the
+// chain, so the rebuilt state has none.
+func rebuildDepositLimits(bc *core.BlockChain, configs map[string]*params.AstriaBridgeAddressConfig, parent *types.Header) (*DepositLimits, error) {
+	var windowBlocks uint64
+	for _, bac := range configs {
+		if bac.RateLimit != nil && bac.RateLimit.WindowBlocks > windowBlocks {
+			windowBlocks = bac.RateLimit.WindowBlocks
+		}
+	}
+
+	limits := &DepositLimits{Bridges: make(map[string]*bridgeDepositLimits)}
+	hash, number := parent.Hash(), parent.Number.Uint64()
+	for i := uint64(0); i < windowBlocks && number > 0; i++ {
+		block := bc.GetBlock(hash, number)
+		if block == nil {
+			return nil, fmt.Errorf("block %s not found", hash)
+		}
+		minted := make(map[string]*big.Int)
+		for _, tx := range block.Transactions() {
+			bac, amount := depositedAmount(bc.Config(), number, tx)
+			if bac == nil {
+				continue
+			}
+			if limited, ok := configs[bac.BridgeAddress]; !ok || !limited.RateLimit.ActiveAt(number) || limited.RateLimit.MaxWindowAmount == nil {
+				continue
+			}
+			if _, ok := minted[bac.BridgeAddress]; !ok {
+				minted[bac.BridgeAddress] = new(big.Int)
+			}
+			minted[bac.BridgeAddress].Add(minted[bac.BridgeAddress], amount)
+		}
+		// blocks are visited newest first, while windows hold the oldest first
+		for bridgeAddress, amount := range minted {
+			bridgeLimits, ok := limits.Bridges[bridgeAddress]
+			if !ok {
+				bridgeLimits = &bridgeDepositLimits{}
+				limits.Bridges[bridgeAddress] = bridgeLimits
+			}
+			bridgeLimits.Window = append([]mintedAtHeight{{Height: number, Amount: amount}}, bridgeLimits.Window...)
+		}
+		hash, number = block.ParentHash(), number-1
+	}
+	return limits, nil
+}
+
+// depositedAmount returns the config of the bridge which created the given deposit tx at the
+// given height, and the amount deposited on the sequencer. The bridge is nil if the tx is not
+// a deposit of a bridge configured at the height.
+func depositedAmount(config *params.ChainConfig, height uint64, tx *types.Transaction) (*params.AstriaBridgeAddressConfig, *big.Int) {
+	if tx.Type() != types.DepositTxType {
+		return nil, nil
+	}
+	bac := config.AstriaDepositBridge(height, tx.From(), tx.To())
+	if bac == nil {
+		return nil, nil
+	}
+	scaled := tx.Value()
+	if bac.Erc20Asset != nil {
+		// the amount is the second argument of the `mint(address,uint256)` calldata
+		data := tx.Data()
+		if len(data) < 4+2*32 {
+			return nil, nil
+		}
+		scaled = new(big.Int).SetBytes(data[4+32 : 4+2*32])
+	}
+	return bac, bac.UnscaledDepositAmount(scaled)
+}
+
+// depositLimiter enforces the bridge rate limits while the deposits of a single block are
+// unbundled.
+type depositLimiter struct {
+	height  uint64
+	configs map[string]*params.AstriaBridgeAddressConfig
+	state   *DepositLimits
+
+	// amount minted by each bridge in the block being built
+	minted map[string]*big.Int
+}
+
+func newDepositLimiter(parent *DepositLimits, height uint64, configs map[string]*params.AstriaBridgeAddressConfig) *depositLimiter {
+	state := &DepositLimits{Bridges: make(map[string]*bridgeDepositLimits)}
+	for bridgeAddress, bridgeLimits := range parent.Bridges {
+		bac, ok := configs[bridgeAddress]
+		if !ok {
+			continue
+		}
+
+		// if the rate limit has been removed from the config or does not apply yet, the
+		// window is dropped but deferred deposits are kept so that they are all released in this block.
+		window := []mintedAtHeight{}
+		if bac.RateLimit.ActiveAt(height) {
+			for _, entry := range bridgeLimits.Window {
+				if entry.Height+bac.RateLimit.WindowBlocks > height {
+					window = append(window, entry)
+				}
+			}
+		}
+		state.Bridges[bridgeAddress] = &bridgeDepositLimits{
+			Window:   window,
+			Deferred: append([][]byte{}, bridgeLimits.Deferred...),
+		}
+	}
+
+	return &depositLimiter{
+		height:  height,
+		configs: configs,
+		state:   state,
+		minted:  make(map[string]*big.Int),
+	}
+}
+
+// rateLimit returns the rate limit of the bridge applying to the block being built, if any.
+func (l *depositLimiter) rateLimit(bridgeAddress string) *params.AstriaDepositRateLimit {
+	bac, ok := l.configs[bridgeAddress]
+	if !ok || !bac.RateLimit.ActiveAt(l.height) {
+		return nil
+	}
+	return bac.RateLimit
+}
+
+func (l *depositLimiter) bridge(bridgeAddress string) *bridgeDepositLimits {
+	bridgeLimits, ok := l.state.Bridges[bridgeAddress]
+	if !ok {
+		bridgeLimits = &bridgeDepositLimits{}
+		l.state.Bridges[bridgeAddress] = bridgeLimits
+	}
+	return bridgeLimits
+}
+
+// fits returns whether minting amount through the bridge keeps it under its block and window caps.
+func (l *depositLimiter) fits(bridgeAddress string, rateLimit *params.AstriaDepositRateLimit, amount *big.Int) bool {
+	if rateLimit == nil {
+		return true
+	}
+	minted := new(big.Int).Add(amount, l.mintedInBlock(bridgeAddress))
+	if rateLimit.MaxBlockAmount != nil && minted.Cmp(rateLimit.MaxBlockAmount) > 0 {
+		return false
+	}
+	if rateLimit.MaxWindowAmount != nil {
+		for _, entry := range l.bridge(bridgeAddress).Window {
+			minted.Add(minted, entry.Amount)
+		}
+		if minted.Cmp(rateLimit.MaxWindowAmount) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *depositLimiter) mintedInBlock(bridgeAddress string) *big.Int {
+	minted, ok := l.minted[bridgeAddress]
+	if !ok {
+		return new(big.Int)
+	}
+	return minted
+}
+
+func (l *depositLimiter) record(bridgeAddress string, amount *big.Int) {
+	l.minted[bridgeAddress] = new(big.Int).Add(l.mintedInBlock(bridgeAddress), amount)
+}
+
+// releaseDeferred returns the deferred deposits which fit in the current block, in order.
+// Bridges are visited in lexicographic order of their address so that the result does not
+// depend on map iteration order.
+func (l *depositLimiter) releaseDeferred() []*sequencerblockv1.Deposit {
+	bridgeAddresses := make([]string, 0, len(l.state.Bridges))
+	for bridgeAddress := range l.state.Bridges {
+		bridgeAddresses = append(bridgeAddresses, bridgeAddress)
+	}
+	sort.Strings(bridgeAddresses)
+
+	released := []*sequencerblockv1.Deposit{}
+	for _, bridgeAddress := range bridgeAddresses {
+		bridgeLimits := l.state.Bridges[bridgeAddress]
+		rateLimit := l.rateLimit(bridgeAddress)
+
+		for len(bridgeLimits.Deferred) > 0 {
+			deposit := &sequencerblockv1.Deposit{}
+			if err := proto.Unmarshal(bridgeLimits.Deferred[0], deposit); err != nil {
+				// this should never happen, as we only store deposits we have marshalled ourselves
+				log.Error("failed to unmarshal deferred deposit, dropping it", "bridgeAddress", bridgeAddress, "error", err)
+				bridgeLimits.Deferred = bridgeLimits.Deferred[1:]
+				continue
+			}
+			amount := protoU128ToBigInt(deposit.Amount)
+			// deferred deposits are minted strictly in order, so stop at the first one that doesn't fit
+			if !l.fits(bridgeAddress, rateLimit, amount) {
+				break
+			}
+			l.record(bridgeAddress, amount)
+			bridgeLimits.Deferred = bridgeLimits.Deferred[1:]
+			released = append(released, deposit)
+		}
+	}
+	depositsReleased.Inc(int64(len(released)))
+
+	return released
+}
+
+// admit checks a deposit of this block against the rate limit of its bridge, recording the
+// minted amount if it is admitted and queueing it if it is deferred.
+func (l *depositLimiter) admit(deposit *sequencerblockv1.Deposit) depositDecision {
+	bridgeAddress := deposit.BridgeAddress.GetBech32M()
+	rateLimit := l.rateLimit(bridgeAddress)
+	if rateLimit == nil {
+		return depositAdmitted
+	}
+	amount := protoU128ToBigInt(deposit.Amount)
+
+	if rateLimit.MaxDepositAmount != nil && amount.Cmp(rateLimit.MaxDepositAmount) > 0 {
+		log.Warn("deposit exceeds bridge max deposit amount, rejecting", "bridgeAddress", bridgeAddress, "amount", amount, "maxDepositAmount", rateLimit.MaxDepositAmount)
+		depositsRateLimited.Inc(1)
+		return depositRejected
+	}
+
+	bridgeLimits := l.bridge(bridgeAddress)
+	// deposits already waiting in the queue go first, regardless of whether this one fits
+	if len(bridgeLimits.Deferred) == 0 && l.fits(bridgeAddress, rateLimit, amount) {
+		l.record(bridgeAddress, amount)
+		return depositAdmitted
+	}
+
+	if rateLimit.Policy() == params.AstriaDepositOverLimitReject {
+		log.Warn("deposit exceeds bridge rate limit, rejecting", "bridgeAddress", bridgeAddress, "amount", amount)
+		depositsRateLimited.Inc(1)
+		return depositRejected
+	}
+
+	if uint64(len(bridgeLimits.Deferred)) >= rateLimit.DeferredDepositsLimit() {
+		log.Warn("deferred deposits queue is full, rejecting deposit", "bridgeAddress", bridgeAddress, "amount", amount, "queueSize", len(bridgeLimits.Deferred))
+		depositsRateLimited.Inc(1)
+		return depositRejected
+	}
+
+	marshalledDeposit, err := proto.Marshal(deposit)
+	if err != nil {
+		log.Error("failed to marshal deposit to defer it, rejecting", "bridgeAddress", bridgeAddress, "error", err)
+		depositsRateLimited.Inc(1)
+		return depositRejected
+	}
+	log.Info("deposit exceeds bridge rate limit, deferring", "bridgeAddress", bridgeAddress, "amount", amount, "queueSize", len(bridgeLimits.Deferred)+1)
+	bridgeLimits.Deferred = append(bridgeLimits.Deferred, marshalledDeposit)
+	depositsDeferred.Inc(1)
+	return depositDeferred
+}
+
+// finalize returns the rate limiter state after the block, adding this block's minted
+// amounts to the rolling windows.
+func (l *depositLimiter) finalize() *DepositLimits {
+	for bridgeAddress, minted := range l.minted {
+		rateLimit := l.rateLimit(bridgeAddress)
+		if rateLimit == nil || rateLimit.MaxWindowAmount == nil {
+			continue
+		}
+		bridgeLimits := l.bridge(bridgeAddress)
+		bridgeLimits.Window = append(bridgeLimits.Window, mintedAtHeight{Height: l.height, Amount: minted})
+	}
+	return l.state
+}
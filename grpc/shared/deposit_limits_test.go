@@ -0,0 +1,231 @@
+package shared
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	primitivev1 "buf.build/gen/go/astria/primitives/protocolbuffers/go/astria/primitive/v1"
+	sequencerblockv1 "buf.build/gen/go/astria/sequencerblock-apis/protocolbuffers/go/astria/sequencerblock/v1"
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func depositRollupData(bridgeAddress string, assetDenom string, amount int64, index uint64) *sequencerblockv1.RollupData {
+	return &sequencerblockv1.RollupData{Value: &sequencerblockv1.RollupData_Deposit{Deposit: &sequencerblockv1.Deposit{
+		BridgeAddress: &primitivev1.Address{
+			Bech32M: bridgeAddress,
+		},
+		Asset:                   assetDenom,
+		Amount:                  BigIntToProtoU128(big.NewInt(amount)),
+		RollupId:                &primitivev1.RollupId{Inner: make([]byte, 0)},
+		DestinationChainAddress: TestToAddress.String(),
+		SourceTransactionId: &primitivev1.TransactionId{
+			Inner: "test_tx_hash",
+		},
+		SourceActionIndex: index,
+	}}}
+}
+
+func depositSourceIndices(t *testing.T, txs types.Transactions) []uint64 {
+	t.Helper()
+	indices := []uint64{}
+	for _, tx := range txs {
+		require.Equal(t, uint8(types.DepositTxType), tx.Type(), "expected only deposit txs")
+		encoded, err := tx.MarshalBinary()
+		require.NoError(t, err, "failed to marshal deposit tx")
+		var deposit types.DepositTx
+		require.NoError(t, rlp.DecodeBytes(encoded[1:], &deposit), "failed to decode deposit tx")
+		indices = append(indices, deposit.SourceTransactionIndex)
+	}
+	return indices
+}
+
+func depositSender(t *testing.T, tx *types.Transaction) common.Address {
+	t.Helper()
+	encoded, err := tx.MarshalBinary()
+	require.NoError(t, err, "failed to marshal deposit tx")
+	var deposit types.DepositTx
+	require.NoError(t, rlp.DecodeBytes(encoded[1:], &deposit), "failed to decode deposit tx")
+	return deposit.From
+}
+
+func writeTestDepositLimits(t *testing.T, sharedService *SharedServiceContainer, hash common.Hash, limits *DepositLimits) {
+	t.Helper()
+	data, err := json.Marshal(limits)
+	require.NoError(t, err, "failed to encode deposit limits")
+	rawdb.WriteAstriaDepositLimits(sharedService.Eth().ChainDb(), hash, data)
+}
+
+func TestUnbundleRollupDataDepositRateLimitDefer(t *testing.T) {
+	ethservice, sharedService, _, _ := SetupSharedService(t, 10)
+
+	bridgeAddress := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].BridgeAddress
+	bridgeAssetDenom := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].AssetDenom
+	sharedService.BridgeAddresses()[bridgeAddress].RateLimit = &params.AstriaDepositRateLimit{
+		StartHeight:      2,
+		MaxDepositAmount: big.NewInt(5),
+		MaxBlockAmount:   big.NewInt(2),
+		MaxWindowAmount:  big.NewInt(3),
+		WindowBlocks:     2,
+	}
+	sharedService.depositRateLimited = true
+
+	// block 2: the first two deposits fit, the third one is deferred and the fourth one is above the max deposit amount
+	parentHash := common.HexToHash("0x01")
+	txs, limits, err := sharedService.UnbundleRollupDataTransactions([]*sequencerblockv1.RollupData{
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 1, 0),
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 1, 1),
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 1, 2),
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 10, 3),
+	}, 2, parentHash.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0, 1}, depositSourceIndices(t, txs))
+	require.Len(t, limits.Bridges[bridgeAddress].Deferred, 1)
+
+	block2Hash := common.HexToHash("0x02")
+	writeTestDepositLimits(t, sharedService, block2Hash, limits)
+
+	// block 3: the deferred deposit is released first, the new one would exceed the window cap and is deferred
+	txs, limits, err = sharedService.UnbundleRollupDataTransactions([]*sequencerblockv1.RollupData{
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 1, 4),
+	}, 3, block2Hash.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, depositSourceIndices(t, txs))
+	require.Len(t, limits.Bridges[bridgeAddress].Deferred, 1)
+
+	block3Hash := common.HexToHash("0x03")
+	writeTestDepositLimits(t, sharedService, block3Hash, limits)
+
+	// block 4: block 2 left the window, so the deferred deposit is minted ahead of the new one
+	txs, limits, err = sharedService.UnbundleRollupDataTransactions([]*sequencerblockv1.RollupData{
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 1, 5),
+	}, 4, block3Hash.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{4, 5}, depositSourceIndices(t, txs))
+	require.Empty(t, limits.Bridges[bridgeAddress].Deferred)
+
+	// rebuilding block 3 on top of block 2 yields the same result
+	txs, _, err = sharedService.UnbundleRollupDataTransactions([]*sequencerblockv1.RollupData{
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 1, 4),
+	}, 3, block2Hash.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, depositSourceIndices(t, txs))
+
+	// a block cannot be built on top of a rate limited block without a limiter state
+	_, _, err = sharedService.UnbundleRollupDataTransactions([]*sequencerblockv1.RollupData{
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 1, 6),
+	}, 4, common.HexToHash("0x09").Bytes())
+	require.Error(t, err)
+}
+
+func TestUnbundleRollupDataDepositRateLimitStartHeight(t *testing.T) {
+	ethservice, sharedService, _, _ := SetupSharedService(t, 10)
+
+	bridgeAddress := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].BridgeAddress
+	bridgeAssetDenom := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].AssetDenom
+	sharedService.BridgeAddresses()[bridgeAddress].RateLimit = &params.AstriaDepositRateLimit{
+		StartHeight:     3,
+		MaxBlockAmount:  big.NewInt(1),
+		OverLimitPolicy: params.AstriaDepositOverLimitReject,
+	}
+	sharedService.depositRateLimited = true
+
+	// the rate limit does not apply before its start height
+	rollupData := []*sequencerblockv1.RollupData{
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 1, 0),
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 1, 1),
+	}
+	txs, limits, err := sharedService.UnbundleRollupDataTransactions(rollupData, 2, common.HexToHash("0x01").Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0, 1}, depositSourceIndices(t, txs))
+
+	block2Hash := common.HexToHash("0x02")
+	writeTestDepositLimits(t, sharedService, block2Hash, limits)
+	txs, _, err = sharedService.UnbundleRollupDataTransactions(rollupData, 3, block2Hash.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0}, depositSourceIndices(t, txs))
+}
+
+func TestUnbundleRollupDataDepositRateLimitReject(t *testing.T) {
+	ethservice, sharedService, _, _ := SetupSharedService(t, 10)
+
+	bridgeAddress := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].BridgeAddress
+	bridgeAssetDenom := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].AssetDenom
+	sharedService.BridgeAddresses()[bridgeAddress].RateLimit = &params.AstriaDepositRateLimit{
+		StartHeight:     2,
+		MaxBlockAmount:  big.NewInt(2),
+		OverLimitPolicy: params.AstriaDepositOverLimitReject,
+	}
+	sharedService.depositRateLimited = true
+
+	txs, limits, err := sharedService.UnbundleRollupDataTransactions([]*sequencerblockv1.RollupData{
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 2, 0),
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 1, 1),
+	}, 2, common.HexToHash("0x01").Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0}, depositSourceIndices(t, txs))
+	require.Empty(t, limits.Bridges[bridgeAddress].Deferred)
+}
+
+func TestUnbundleRollupDataWithoutDepositRateLimit(t *testing.T) {
+	ethservice, sharedService, _, _ := SetupSharedService(t, 10)
+
+	bridgeAddress := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].BridgeAddress
+	bridgeAssetDenom := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].AssetDenom
+
+	txs, limits, err := sharedService.UnbundleRollupDataTransactions([]*sequencerblockv1.RollupData{
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 1, 0),
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 1000, 1),
+	}, 2, common.HexToHash("0x01").Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0, 1}, depositSourceIndices(t, txs))
+	require.Nil(t, limits)
+}
+
+func TestUnbundleRollupDataDepositRateLimitRebuild(t *testing.T) {
+	ethservice, sharedService, _, _ := SetupSharedService(t, 10)
+
+	bridgeAddress := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].BridgeAddress
+	bridgeAssetDenom := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].AssetDenom
+	sharedService.BridgeAddresses()[bridgeAddress].RateLimit = &params.AstriaDepositRateLimit{
+		StartHeight:     1,
+		MaxWindowAmount: big.NewInt(3),
+		WindowBlocks:    2,
+	}
+	sharedService.depositRateLimited = true
+
+	// the head was not built by this node, so its limiter state is rebuilt from the chain
+	head := ethservice.BlockChain().CurrentBlock()
+	txs, _, err := sharedService.UnbundleRollupDataTransactions([]*sequencerblockv1.RollupData{
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 2, 0),
+	}, head.Number.Uint64()+1, head.Hash().Bytes())
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0}, depositSourceIndices(t, txs))
+
+	// insert the block without its limiter state, as if it was synced from a peer
+	payload, err := ethservice.Miner().BuildPayload(&miner.BuildPayloadArgs{
+		Parent:       head.Hash(),
+		Timestamp:    head.Time + 1,
+		Transactions: txs,
+	})
+	require.NoError(t, err, "failed to build payload")
+	block, err := engine.ExecutableDataToBlock(*payload.Resolve().ExecutionPayload, nil, nil)
+	require.NoError(t, err, "failed to convert payload to block")
+	require.NoError(t, sharedService.InsertBlockWithoutSetHead(block, nil), "failed to insert block")
+
+	// the amount minted by the block counts towards the rebuilt window
+	txs, limits, err := sharedService.UnbundleRollupDataTransactions([]*sequencerblockv1.RollupData{
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 2, 1),
+	}, block.NumberU64()+1, block.Hash().Bytes())
+	require.NoError(t, err)
+	require.Empty(t, txs)
+	require.Len(t, limits.Bridges[bridgeAddress].Deferred, 1)
+	require.Equal(t, []mintedAtHeight{{Height: block.NumberU64(), Amount: big.NewInt(2)}}, limits.Bridges[bridgeAddress].Window)
+}
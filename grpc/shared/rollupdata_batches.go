@@ -0,0 +1,141 @@
+package shared
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/andybalholm/brotli"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/klauspost/compress/zstd"
+	"io"
+)
+
+const (
+	// RollupDataKindZstdBatch is a zstd compressed batch of transactions.
+	RollupDataKindZstdBatch RollupDataKind = 0x02
+	// RollupDataKindBrotliBatch is a brotli compressed batch of transactions.
+	RollupDataKindBrotliBatch RollupDataKind = 0x03
+
+	// ZstdBatchDecoderName enables zstd batches in `AstriaRollupDataDecoders`.
+	ZstdBatchDecoderName = "zstdBatch"
+	// BrotliBatchDecoderName enables brotli batches in `AstriaRollupDataDecoders`.
+	BrotliBatchDecoderName = "brotliBatch"
+
+	// MaxRollupDataBatchSize is the maximum decompressed size of a batch. Batches which
+	// decompress to more than this are dropped as a whole.
+	MaxRollupDataBatchSize = 4 * 1024 * 1024
+	// MaxRollupDataBlockBatchSize is the maximum total decompressed size of the batches of
+	// a block. Batches which decompress beyond it are dropped as a whole.
+	MaxRollupDataBlockBatchSize = 16 * 1024 * 1024
+)
+
+var (
+	invalidRollupDataBatches = metrics.GetOrRegisterCounter("astria/rollupdata/invalid_batches", nil)
+	batchedTxs               = metrics.GetOrRegisterCounter("astria/rollupdata/batched_txs", nil)
+	invalidBatchedTxs        = metrics.GetOrRegisterCounter("astria/rollupdata/invalid_batched_txs", nil)
+)
+
+// batchDecoder decodes a compressed batch of transactions. Once decompressed, a batch is the
+// RLP encoding of a list of marshalled transactions, each of which is validated like the
+// sequenced data of a single transaction. Invalid transactions are skipped.
+type batchDecoder struct {
+	decompress func(payload []byte, limit int) ([]byte, error)
+}
+
+func (d batchDecoder) Decode(ctx *RollupDataDecodeContext, payload []byte) (*DecodedRollupData, error) {
+	limit := min(MaxRollupDataBatchSize, MaxRollupDataBlockBatchSize-ctx.decompressedSize)
+	if limit <= 0 {
+		invalidRollupDataBatches.Inc(1)
+		return nil, fmt.Errorf("block exceeds decompressed batch size limit of %d bytes", MaxRollupDataBlockBatchSize)
+	}
+	decompressed, err := d.decompress(payload, limit)
+	// the data decompressed by failed batches also counts towards the limit of the block
+	ctx.decompressedSize += len(decompressed)
+	if err != nil {
+		invalidRollupDataBatches.Inc(1)
+		return nil, WrapError(err, "failed to decompress batch")
+	}
+	var marshalledTxs [][]byte
+	if err := rlp.DecodeBytes(decompressed, &marshalledTxs); err != nil {
+		invalidRollupDataBatches.Inc(1)
+		return nil, WrapError(err, "failed to decode batch")
+	}
+
+	txs := types.Transactions{}
+	for i, marshalledTx := range marshalledTxs {
+		tx, err := unmarshalSequencedTx(marshalledTx)
+		if err != nil {
+			log.Error("failed to unmarshal batched tx", "index", i, "error", err)
+			invalidBatchedTxs.Inc(1)
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	batchedTxs.Inc(int64(len(txs)))
+
+	return &DecodedRollupData{Txs: txs}, nil
+}
+
+// readLimited reads at most limit bytes from r, failing if there is more. The data read
+// is returned even on failure.
+func readLimited(r io.Reader, limit int) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if err != nil {
+		return data, err
+	}
+	if len(data) > limit {
+		return data, fmt.Errorf("decompressed size exceeds limit of %d bytes", limit)
+	}
+	return data, nil
+}
+
+func decompressZstd(payload []byte, limit int) ([]byte, error) {
+	decoder, err := zstd.NewReader(bytes.NewReader(payload), zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(uint64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	defer decoder.Close()
+	return readLimited(decoder, limit)
+}
+
+func decompressBrotli(payload []byte, limit int) ([]byte, error) {
+	return readLimited(brotli.NewReader(bytes.NewReader(payload)), limit)
+}
+
+// EncodeRollupDataBatch compresses the marshalled transactions into the sequenced data of a
+// batch envelope of the given kind.
+func EncodeRollupDataBatch(kind RollupDataKind, marshalledTxs [][]byte) ([]byte, error) {
+	encoded, err := rlp.EncodeToBytes(marshalledTxs)
+	if err != nil {
+		return nil, err
+	}
+
+	var compressed bytes.Buffer
+	switch kind {
+	case RollupDataKindZstdBatch:
+		encoder, err := zstd.NewWriter(&compressed)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := encoder.Write(encoded); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	case RollupDataKindBrotliBatch:
+		encoder := brotli.NewWriter(&compressed)
+		if _, err := encoder.Write(encoded); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("rollup data kind %d is not a batch", kind)
+	}
+
+	return EncodeRollupDataEnvelope(kind, compressed.Bytes()), nil
+}
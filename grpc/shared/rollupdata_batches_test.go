@@ -0,0 +1,141 @@
+package shared
+
+import (
+	sequencerblockv1 "buf.build/gen/go/astria/sequencerblock-apis/protocolbuffers/go/astria/sequencerblock/v1"
+	"bytes"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnbundleRollupDataBatches(t *testing.T) {
+	ethservice, serviceV1Alpha1, _, _ := SetupSharedService(t, 10)
+	config := ethservice.BlockChain().Config()
+	config.AstriaRollupDataEnvelopeHeight = 1
+	config.AstriaRollupDataDecoders = map[string]uint32{ZstdBatchDecoderName: 3, BrotliBatchDecoderName: 3}
+	defer func() {
+		config.AstriaRollupDataEnvelopeHeight = 0
+		config.AstriaRollupDataDecoders = nil
+	}()
+
+	tx1 := transaction(0, 1000, TestKey)
+	tx2 := transaction(1, 1000, TestKey)
+	tx3 := transaction(2, 1000, TestKey)
+	marshalledTxs := [][]byte{}
+	for _, tx := range []*types.Transaction{tx1, testDepositTx(), tx2, testBlobTx()} {
+		marshalledTx, err := tx.MarshalBinary()
+		require.NoError(t, err, "failed to marshal tx")
+		marshalledTxs = append(marshalledTxs, marshalledTx)
+	}
+	marshalledTxs = append(marshalledTxs, []byte("unmarshallable tx"))
+	marshalledTx3, err := tx3.MarshalBinary()
+	require.NoError(t, err, "failed to marshal tx")
+
+	for _, kind := range []RollupDataKind{RollupDataKindZstdBatch, RollupDataKindBrotliBatch} {
+		batch, err := EncodeRollupDataBatch(kind, marshalledTxs)
+		require.NoError(t, err, "failed to encode batch")
+		rollupData := []*sequencerblockv1.RollupData{
+			sequencedRollupData(batch),
+			sequencedRollupData(marshalledTx3),
+		}
+
+		// before the batch decoders are enabled, the batch is dropped
+		txs, _, err := serviceV1Alpha1.UnbundleRollupDataTransactions(rollupData, 2, []byte("prev rollup block hash"))
+		require.NoError(t, err)
+		requireTxHashes(t, types.Transactions{tx3}, txs)
+
+		// invalid txs of the batch are skipped, the valid ones are kept in order
+		txs, _, err = serviceV1Alpha1.UnbundleRollupDataTransactions(rollupData, 3, []byte("prev rollup block hash"))
+		require.NoError(t, err)
+		requireTxHashes(t, types.Transactions{tx1, tx2, tx3}, txs)
+	}
+}
+
+func TestBatchDecoderSizeLimit(t *testing.T) {
+	oversized, err := rlp.EncodeToBytes([][]byte{make([]byte, MaxRollupDataBatchSize)})
+	require.NoError(t, err, "failed to encode batch")
+
+	var zstdPayload bytes.Buffer
+	zstdEncoder, err := zstd.NewWriter(&zstdPayload)
+	require.NoError(t, err, "failed to create zstd encoder")
+	_, err = zstdEncoder.Write(oversized)
+	require.NoError(t, err, "failed to compress batch")
+	require.NoError(t, zstdEncoder.Close(), "failed to compress batch")
+
+	var brotliPayload bytes.Buffer
+	brotliEncoder := brotli.NewWriter(&brotliPayload)
+	_, err = brotliEncoder.Write(oversized)
+	require.NoError(t, err, "failed to compress batch")
+	require.NoError(t, brotliEncoder.Close(), "failed to compress batch")
+
+	tests := []struct {
+		description string
+		decoder     batchDecoder
+		payload     []byte
+		wantErr     string
+	}{
+		{
+			description: "zstd batch above the size limit",
+			decoder:     batchDecoder{decompress: decompressZstd},
+			payload:     zstdPayload.Bytes(),
+			wantErr:     "failed to decompress batch",
+		},
+		{
+			description: "brotli batch above the size limit",
+			decoder:     batchDecoder{decompress: decompressBrotli},
+			payload:     brotliPayload.Bytes(),
+			wantErr:     "decompressed size exceeds limit",
+		},
+		{
+			description: "corrupt zstd batch",
+			decoder:     batchDecoder{decompress: decompressZstd},
+			payload:     []byte("not zstd"),
+			wantErr:     "failed to decompress batch",
+		},
+		{
+			description: "corrupt brotli batch",
+			decoder:     batchDecoder{decompress: decompressBrotli},
+			payload:     []byte("not brotli"),
+			wantErr:     "failed to decompress batch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			require.Less(t, len(test.payload), MaxRollupDataBatchSize, "payload should be compressed")
+			_, err := test.decoder.Decode(&RollupDataDecodeContext{}, test.payload)
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestBatchDecoderBlockSizeLimit(t *testing.T) {
+	batch, err := rlp.EncodeToBytes([][]byte{make([]byte, MaxRollupDataBatchSize-16)})
+	require.NoError(t, err, "failed to encode batch")
+
+	var payload bytes.Buffer
+	encoder := brotli.NewWriter(&payload)
+	_, err = encoder.Write(batch)
+	require.NoError(t, err, "failed to compress batch")
+	require.NoError(t, encoder.Close(), "failed to compress batch")
+
+	// the batches of a block decode until their total decompressed size reaches the limit
+	decoder := batchDecoder{decompress: decompressBrotli}
+	ctx := &RollupDataDecodeContext{}
+	for i := 0; i < MaxRollupDataBlockBatchSize/MaxRollupDataBatchSize; i++ {
+		_, err := decoder.Decode(ctx, payload.Bytes())
+		require.NoError(t, err, "batch %d should decode", i)
+	}
+	_, err = decoder.Decode(ctx, payload.Bytes())
+	require.ErrorContains(t, err, "failed to decompress batch")
+	_, err = decoder.Decode(ctx, payload.Bytes())
+	require.ErrorContains(t, err, "block exceeds decompressed batch size limit")
+
+	// the limit applies per block
+	_, err = decoder.Decode(&RollupDataDecodeContext{}, payload.Bytes())
+	require.NoError(t, err)
+}
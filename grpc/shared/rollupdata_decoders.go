@@ -0,0 +1,135 @@
+package shared
+
+import (
+	auctionv1alpha1 "buf.build/gen/go/astria/execution-apis/protocolbuffers/go/astria/auction/v1alpha1"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"google.golang.org/protobuf/proto"
+)
+
+// RollupDataEnvelopePrefix is the first byte of enveloped sequenced data. It is followed by
+// a single `RollupDataKind` byte and the payload. The prefix is an RLP string header, so it
+// never collides with the encoding of an Ethereum transaction, which is either a typed
+// transaction (first byte <= 0x7f) or an RLP list (first byte >= 0xc0).
+const RollupDataEnvelopePrefix byte = 0xae
+
+// RollupDataKind identifies the decoder of an enveloped sequenced data payload.
+type RollupDataKind byte
+
+const (
+	// RollupDataKindAllocation is a protobuf encoded `Allocation` of the auctioneer.
+	RollupDataKindAllocation RollupDataKind = 0x01
+)
+
+var (
+	invalidRollupDataEnvelopes = metrics.GetOrRegisterCounter("astria/rollupdata/invalid_envelopes", nil)
+)
+
+// RollupDataDecodeContext is the block being built when a rollup data payload is decoded.
+type RollupDataDecodeContext struct {
+	Height        uint64
+	PrevBlockHash []byte
+	Service       *SharedServiceContainer
+
+	decompressedSize int // Total decompressed size of the batches of the block so far
+}
+
+// DecodedRollupData is the result of decoding a rollup data payload.
+type DecodedRollupData struct {
+	Txs types.Transactions
+	// TopOfBlock txs are placed ahead of all other txs of the block. Only the first top of
+	// block payload of a block is used, any other one is ignored.
+	TopOfBlock bool
+}
+
+// RollupDataDecoder decodes the payload of an enveloped sequenced data into rollup transactions.
+type RollupDataDecoder interface {
+	Decode(ctx *RollupDataDecodeContext, payload []byte) (*DecodedRollupData, error)
+}
+
+// rollupDataDecoderEntry is a decoder registered for a kind of payload. Builtin decoders are
+// enabled together with the envelope, other ones are enabled by `AstriaRollupDataDecoders`.
+type rollupDataDecoderEntry struct {
+	name    string
+	builtin bool
+	decoder RollupDataDecoder
+}
+
+// defaultRollupDataDecoders returns the decoders shipped with the node. Apart from the builtin
+// ones, they still have to be enabled by `AstriaRollupDataDecoders`.
+func defaultRollupDataDecoders() map[RollupDataKind]*rollupDataDecoderEntry {
+	return map[RollupDataKind]*rollupDataDecoderEntry{
+		RollupDataKindAllocation:  {name: "allocation", builtin: true, decoder: allocationDecoder{}},
+		RollupDataKindZstdBatch:   {name: ZstdBatchDecoderName, decoder: batchDecoder{decompress: decompressZstd}},
+		RollupDataKindBrotliBatch: {name: BrotliBatchDecoderName, decoder: batchDecoder{decompress: decompressBrotli}},
+	}
+}
+
+// RegisterRollupDataDecoder adds a decoder for the given kind of payload. The decoder is only
+// used from the height configured for its name in `AstriaRollupDataDecoders`.
+func (s *SharedServiceContainer) RegisterRollupDataDecoder(kind RollupDataKind, name string, decoder RollupDataDecoder) error {
+	if existing, ok := s.rollupDataDecoders[kind]; ok {
+		return fmt.Errorf("rollup data kind %d is already registered to decoder %s", kind, existing.name)
+	}
+	for _, entry := range s.rollupDataDecoders {
+		if entry.name == name {
+			return fmt.Errorf("rollup data decoder %s is already registered", name)
+		}
+	}
+	s.rollupDataDecoders[kind] = &rollupDataDecoderEntry{name: name, decoder: decoder}
+	return nil
+}
+
+// EncodeRollupDataEnvelope wraps a payload into a sequenced data envelope of the given kind.
+func EncodeRollupDataEnvelope(kind RollupDataKind, payload []byte) []byte {
+	return append([]byte{RollupDataEnvelopePrefix, byte(kind)}, payload...)
+}
+
+func isRollupDataEnvelope(data []byte) bool {
+	return len(data) > 0 && data[0] == RollupDataEnvelopePrefix
+}
+
+// decodeRollupDataEnvelope decodes enveloped sequenced data with the decoder registered for
+// its kind, if that decoder is enabled at the height of the block.
+func (s *SharedServiceContainer) decodeRollupDataEnvelope(ctx *RollupDataDecodeContext, data []byte) (*DecodedRollupData, error) {
+	if len(data) < 2 {
+		invalidRollupDataEnvelopes.Inc(1)
+		return nil, errors.New("rollup data envelope is missing its kind")
+	}
+	kind := RollupDataKind(data[1])
+	entry, ok := s.rollupDataDecoders[kind]
+	if !ok {
+		invalidRollupDataEnvelopes.Inc(1)
+		return nil, fmt.Errorf("unknown rollup data kind %d", kind)
+	}
+	if !entry.builtin && !s.bc.Config().IsAstriaRollupDataDecoderEnabled(entry.name, ctx.Height) {
+		invalidRollupDataEnvelopes.Inc(1)
+		return nil, fmt.Errorf("rollup data decoder %s is not enabled at height %d", entry.name, ctx.Height)
+	}
+	decoded, err := entry.decoder.Decode(ctx, data[2:])
+	if err != nil {
+		return nil, WrapError(err, fmt.Sprintf("failed to decode %s rollup data", entry.name))
+	}
+	return decoded, nil
+}
+
+// allocationDecoder decodes the `Allocation` of the auction winner, whose txs go to the top of block.
+type allocationDecoder struct{}
+
+func (allocationDecoder) Decode(ctx *RollupDataDecodeContext, payload []byte) (*DecodedRollupData, error) {
+	auctioneerAddresses := ctx.Service.AuctioneerAddresses(ctx.Height)
+	if len(auctioneerAddresses) == 0 {
+		return nil, fmt.Errorf("allocations are not allowed at height %d", ctx.Height)
+	}
+	allocation := &auctionv1alpha1.Allocation{}
+	if err := proto.Unmarshal(payload, allocation); err != nil {
+		return nil, WrapError(err, "failed to unmarshal allocation")
+	}
+	txs, err := unmarshalAllocationTxs(allocation, ctx.PrevBlockHash, auctioneerAddresses, ctx.Service.Bc().Config().AstriaSequencerAddressPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return &DecodedRollupData{Txs: txs, TopOfBlock: true}, nil
+}
@@ -0,0 +1,148 @@
+package shared
+
+import (
+	auctionv1alpha1 "buf.build/gen/go/astria/execution-apis/protocolbuffers/go/astria/auction/v1alpha1"
+	sequencerblockv1 "buf.build/gen/go/astria/sequencerblock-apis/protocolbuffers/go/astria/sequencerblock/v1"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// testRollupDataDecoder decodes a payload into the test transaction with the nonce given by its first byte.
+type testRollupDataDecoder struct{}
+
+func (testRollupDataDecoder) Decode(ctx *RollupDataDecodeContext, payload []byte) (*DecodedRollupData, error) {
+	if len(payload) != 1 {
+		return nil, errors.New("invalid payload")
+	}
+	return &DecodedRollupData{Txs: types.Transactions{transaction(uint64(payload[0]), 1000, TestKey)}}, nil
+}
+
+func sequencedRollupData(data []byte) *sequencerblockv1.RollupData {
+	return &sequencerblockv1.RollupData{Value: &sequencerblockv1.RollupData_SequencedData{SequencedData: data}}
+}
+
+func signedAllocation(t *testing.T, privKey ed25519.PrivateKey, pubKey ed25519.PublicKey, prevBlockHash []byte, txs ...*types.Transaction) []byte {
+	t.Helper()
+	marshalledTxs := [][]byte{}
+	for _, tx := range txs {
+		marshalledTx, err := tx.MarshalBinary()
+		require.NoError(t, err, "failed to marshal tx")
+		marshalledTxs = append(marshalledTxs, marshalledTx)
+	}
+	bid := &auctionv1alpha1.Bid{
+		Fee:                      100,
+		Transactions:             marshalledTxs,
+		SequencerParentBlockHash: []byte("sequencer block hash"),
+		RollupParentBlockHash:    prevBlockHash,
+	}
+	marshalledBid, err := proto.Marshal(bid)
+	require.NoError(t, err, "failed to marshal bid")
+	signature, err := privKey.Sign(nil, marshalledBid, &ed25519.Options{})
+	require.NoError(t, err, "failed to sign bid")
+
+	allocation, err := (&allocationInfo{signature: signature, publicKey: pubKey, bid: bid}).convertToAllocation()
+	require.NoError(t, err, "failed to convert allocation")
+	marshalledAllocation, err := proto.Marshal(allocation)
+	require.NoError(t, err, "failed to marshal allocation")
+	return marshalledAllocation
+}
+
+func requireTxHashes(t *testing.T, want types.Transactions, got types.Transactions) {
+	t.Helper()
+	require.Equal(t, want.Len(), got.Len(), "unexpected number of txs")
+	for i := range want {
+		require.Equal(t, want[i].Hash(), got[i].Hash(), "unexpected tx at index %d", i)
+	}
+}
+
+func TestUnbundleRollupDataEnvelopes(t *testing.T) {
+	ethservice, serviceV1Alpha1, auctioneerPrivKey, auctioneerPubKey := SetupSharedService(t, 10)
+	config := ethservice.BlockChain().Config()
+	config.AstriaRollupDataEnvelopeHeight = 3
+	config.AstriaRollupDataDecoders = map[string]uint32{"test": 4}
+	defer func() {
+		config.AstriaRollupDataEnvelopeHeight = 0
+		config.AstriaRollupDataDecoders = nil
+	}()
+	require.NoError(t, serviceV1Alpha1.RegisterRollupDataDecoder(0x10, "test", testRollupDataDecoder{}))
+
+	prevBlockHash := []byte("prev rollup block hash")
+	allocationTx := transaction(0, 1000, TestKey)
+	seqTx := transaction(1, 1000, TestKey)
+	marshalledSeqTx, err := seqTx.MarshalBinary()
+	require.NoError(t, err, "failed to marshal tx")
+	allocation := signedAllocation(t, auctioneerPrivKey, auctioneerPubKey, prevBlockHash, allocationTx)
+
+	rollupData := []*sequencerblockv1.RollupData{
+		sequencedRollupData(marshalledSeqTx),
+		sequencedRollupData(allocation),
+		sequencedRollupData(EncodeRollupDataEnvelope(RollupDataKindAllocation, allocation)),
+		sequencedRollupData(EncodeRollupDataEnvelope(0x10, []byte{2})),
+		sequencedRollupData(EncodeRollupDataEnvelope(0x20, []byte{3})),
+	}
+
+	tests := []struct {
+		description string
+		height      uint64
+		want        types.Transactions
+	}{
+		{
+			// the raw allocation is detected by unmarshalling, the envelopes are not valid txs
+			description: "before the envelope height",
+			height:      2,
+			want:        types.Transactions{allocationTx, seqTx},
+		},
+		{
+			// the raw allocation is not a valid tx, the test decoder is not enabled yet
+			description: "at the envelope height",
+			height:      3,
+			want:        types.Transactions{allocationTx, seqTx},
+		},
+		{
+			description: "after the decoder height",
+			height:      4,
+			want:        types.Transactions{allocationTx, seqTx, transaction(2, 1000, TestKey)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			txs, _, err := serviceV1Alpha1.UnbundleRollupDataTransactions(rollupData, test.height, prevBlockHash)
+			require.NoError(t, err)
+			requireTxHashes(t, test.want, txs)
+		})
+	}
+}
+
+func TestUnbundleRollupDataEnvelopeSingleTopOfBlock(t *testing.T) {
+	ethservice, serviceV1Alpha1, auctioneerPrivKey, auctioneerPubKey := SetupSharedService(t, 10)
+	config := ethservice.BlockChain().Config()
+	config.AstriaRollupDataEnvelopeHeight = 1
+	defer func() { config.AstriaRollupDataEnvelopeHeight = 0 }()
+
+	prevBlockHash := []byte("prev rollup block hash")
+	tx1 := transaction(0, 1000, TestKey)
+	tx2 := transaction(1, 1000, TestKey)
+
+	txs, _, err := serviceV1Alpha1.UnbundleRollupDataTransactions([]*sequencerblockv1.RollupData{
+		sequencedRollupData(EncodeRollupDataEnvelope(RollupDataKindAllocation, []byte("invalid allocation"))),
+		sequencedRollupData(EncodeRollupDataEnvelope(RollupDataKindAllocation, signedAllocation(t, auctioneerPrivKey, auctioneerPubKey, prevBlockHash, tx1))),
+		sequencedRollupData(EncodeRollupDataEnvelope(RollupDataKindAllocation, signedAllocation(t, auctioneerPrivKey, auctioneerPubKey, prevBlockHash, tx2))),
+		sequencedRollupData([]byte{RollupDataEnvelopePrefix}),
+	}, 2, prevBlockHash)
+	require.NoError(t, err)
+	requireTxHashes(t, types.Transactions{tx1}, txs)
+}
+
+func TestRegisterRollupDataDecoder(t *testing.T) {
+	_, serviceV1Alpha1, _, _ := SetupSharedService(t, 10)
+
+	require.ErrorContains(t, serviceV1Alpha1.RegisterRollupDataDecoder(RollupDataKindAllocation, "test", testRollupDataDecoder{}), "already registered to decoder allocation")
+	require.NoError(t, serviceV1Alpha1.RegisterRollupDataDecoder(0x10, "test", testRollupDataDecoder{}))
+	require.ErrorContains(t, serviceV1Alpha1.RegisterRollupDataDecoder(0x11, "test", testRollupDataDecoder{}), "decoder test is already registered")
+}
@@ -7,17 +7,22 @@ import (
 	"bytes"
 	"crypto/ed25519"
 	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/contracts"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"math/big"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -28,6 +33,8 @@ var (
 	allocationsWithInvalidSignature     = metrics.GetOrRegisterGauge("astria/optimistic/allocations_with_invalid_signature", nil)
 
 	allocationUnbundlingTimer = metrics.GetOrRegisterTimer("astria/optimistic/allocation_unbundling_time", nil)
+
+	depositsWithInvalidRecipient = metrics.GetOrRegisterCounter("astria/deposits/invalid_recipient", nil)
 )
 
 func WrapError(err error, msg string) error {
@@ -64,7 +71,14 @@ func validateAndUnmarshalDepositTx(
 		return nil, fmt.Errorf("asset %s does not match bridge address %s asset", deposit.Asset, bridgeAddress)
 	}
 
-	recipient := common.HexToAddress(deposit.DestinationChainAddress)
+	if bac.DepositCall != nil && strings.Contains(deposit.DestinationChainAddress, ":") {
+		return depositCallTx(deposit, height, bac)
+	}
+
+	recipient, err := depositRecipient(deposit.DestinationChainAddress, height, bac)
+	if err != nil {
+		return nil, err
+	}
 	amount := bac.ScaledDepositAmount(protoU128ToBigInt(deposit.Amount))
 
 	if bac.Erc20Asset != nil {
@@ -82,18 +96,26 @@ func validateAndUnmarshalDepositTx(
 			return nil, err
 		}
 
+		// if the mint to the recipient fails, the tokens are minted to the failed mint recipient instead
+		var fallbackCalldata []byte
+		if bac.Erc20Asset.FailedMintRecipient != nil {
+			fallbackCalldata, err = abi.Pack("mint", *bac.Erc20Asset.FailedMintRecipient, amount)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		txdata := types.DepositTx{
 			From:  bac.SenderAddress,
 			Value: new(big.Int), // don't need to set this, as we aren't minting the native asset
-			// mints cost ~14k gas, however this can vary based on existing storage, so we add a little extra as buffer.
-			//
 			// the fees are spent from the "bridge account" which is not actually a real account, but is instead some
 			// address defined by consensus, so the gas cost is not actually deducted from any account.
-			Gas:                    64000,
+			Gas:                    bac.Erc20Asset.MintGas(),
 			To:                     &bac.Erc20Asset.ContractAddress,
 			Data:                   calldata,
 			SourceTransactionId:    *deposit.SourceTransactionId,
 			SourceTransactionIndex: deposit.SourceActionIndex,
+			FallbackData:           fallbackCalldata,
 		}
 
 		tx := types.NewTx(&txdata)
@@ -111,25 +133,163 @@ func validateAndUnmarshalDepositTx(
 	return types.NewTx(&txdata), nil
 }
 
+// depositCallTx creates the deposit tx of a deposit whose destination chain address is of the form
+// `<recipient>:<target>:<0x prefixed calldata>`. The amount is minted to an address derived for the deposit,
+// which calls the target with it, and is refunded to the recipient if the call fails. Malformed destinations,
+// deposits to a target which is not allowed by the bridge, and deposits without calldata are minted to the
+// recipient instead. The deposit is only rejected if its recipient is invalid.
+func depositCallTx(deposit *sequencerblockv1.Deposit, height uint64, bac *params.AstriaBridgeAddressConfig) (*types.Transaction, error) {
+	amount := bac.ScaledDepositAmount(protoU128ToBigInt(deposit.Amount))
+	mint := func(recipient common.Address) *types.Transaction {
+		return types.NewTx(&types.DepositTx{
+			From:                   bac.SenderAddress,
+			To:                     &recipient,
+			Value:                  amount,
+			Gas:                    0,
+			SourceTransactionId:    *deposit.SourceTransactionId,
+			SourceTransactionIndex: deposit.SourceActionIndex,
+		})
+	}
+
+	parts := strings.Split(deposit.DestinationChainAddress, ":")
+	if len(parts) != 3 {
+		log.Warn("invalid deposit call destination, minting to recipient", "destination", deposit.DestinationChainAddress, "bridgeAddress", bac.BridgeAddress)
+		recipient, err := depositRecipient(deposit.DestinationChainAddress, height, bac)
+		if err != nil {
+			return nil, err
+		}
+		return mint(recipient), nil
+	}
+	recipient, err := depositRecipient(parts[0], height, bac)
+	if err != nil {
+		return nil, err
+	}
+	calldata, err := hexutil.Decode(parts[2])
+	if !common.IsHexAddress(parts[1]) || err != nil {
+		log.Warn("invalid deposit call target or calldata, minting to recipient", "destination", deposit.DestinationChainAddress, "recipient", recipient, "bridgeAddress", bac.BridgeAddress, "error", err)
+		return mint(recipient), nil
+	}
+	target := common.HexToAddress(parts[1])
+
+	if len(calldata) == 0 || !bac.DepositCall.IsAllowedTarget(target) {
+		log.Warn("deposit call target is not allowed or calldata is empty, minting to recipient", "target", target, "recipient", recipient, "bridgeAddress", bac.BridgeAddress)
+		return mint(recipient), nil
+	}
+
+	log.Debug("creating deposit tx calling contract", "target", target, "recipient", recipient, "bridgeAddress", bac.BridgeAddress)
+	return types.NewTx(&types.DepositTx{
+		From:                   depositCallSender(deposit, bac),
+		To:                     &target,
+		Value:                  amount,
+		Gas:                    bac.DepositCall.GasLimit,
+		Data:                   calldata,
+		SourceTransactionId:    *deposit.SourceTransactionId,
+		SourceTransactionIndex: deposit.SourceActionIndex,
+		RefundRecipient:        recipient,
+	}), nil
+}
+
+// depositCallSender derives the address a deposit call is sent from out of the bridge sender
+// address and the source of the deposit, so that the called contract cannot trust the caller
+// to be a privileged address shared by all the depositors.
+func depositCallSender(deposit *sequencerblockv1.Deposit, bac *params.AstriaBridgeAddressConfig) common.Address {
+	index := make([]byte, 8)
+	binary.BigEndian.PutUint64(index, deposit.SourceActionIndex)
+	hash := crypto.Keccak256(bac.SenderAddress.Bytes(), []byte(deposit.SourceTransactionId.GetInner()), index)
+	return common.BytesToAddress(hash[12:])
+}
+
+// depositRecipient returns the rollup address a deposit is minted to. From the strict recipient
+// height of the bridge on, malformed recipients are replaced by the fallback address of the
+// bridge, or rejected if it has none.
+func depositRecipient(destination string, height uint64, bac *params.AstriaBridgeAddressConfig) (common.Address, error) {
+	if !bac.IsStrictRecipient(height) {
+		return common.HexToAddress(destination), nil
+	}
+	recipient, err := parseDepositRecipient(destination, bac.RequireChecksummedRecipient)
+	if err != nil {
+		depositsWithInvalidRecipient.Inc(1)
+		if bac.InvalidRecipientFallback == nil {
+			return common.Address{}, fmt.Errorf("invalid deposit recipient %s: %w", destination, err)
+		}
+		log.Warn("invalid deposit recipient, minting to fallback address", "destination", destination, "fallback", *bac.InvalidRecipientFallback, "bridgeAddress", bac.BridgeAddress, "error", err)
+		return *bac.InvalidRecipientFallback, nil
+	}
+	return recipient, nil
+}
+
+// parseDepositRecipient strictly parses a hex encoded address with an optional 0x prefix. Mixed
+// case addresses must have a valid EIP-55 checksum, and if requireChecksum is set, so must all
+// addresses.
+func parseDepositRecipient(destination string, requireChecksum bool) (common.Address, error) {
+	hex := destination
+	if has0xPrefix(hex) {
+		hex = hex[2:]
+	}
+	if len(hex) != 2*common.AddressLength {
+		return common.Address{}, fmt.Errorf("address must be %d hex characters, got %d", 2*common.AddressLength, len(hex))
+	}
+	if !common.IsHexAddress(hex) {
+		return common.Address{}, errors.New("address is not hex encoded")
+	}
+	recipient := common.HexToAddress(hex)
+
+	mixedCase := strings.ToLower(hex) != hex && strings.ToUpper(hex) != hex
+	if !mixedCase && requireChecksum {
+		return common.Address{}, errors.New("address is not checksummed")
+	}
+	if mixedCase && recipient.Hex()[2:] != hex {
+		return common.Address{}, errors.New("address has an invalid checksum")
+	}
+	return recipient, nil
+}
+
+// has0xPrefix validates str begins with '0x' or '0X'.
+func has0xPrefix(str string) bool {
+	return len(str) >= 2 && str[0] == '0' && (str[1] == 'x' || str[1] == 'X')
+}
+
+// depositsFirst moves the deposit txs ahead of the other txs, keeping their relative order.
+func depositsFirst(txs types.Transactions) types.Transactions {
+	ordered := make(types.Transactions, 0, len(txs))
+	for _, tx := range txs {
+		if tx.Type() == types.DepositTxType {
+			ordered = append(ordered, tx)
+		}
+	}
+	for _, tx := range txs {
+		if tx.Type() != types.DepositTxType {
+			ordered = append(ordered, tx)
+		}
+	}
+	return ordered
+}
+
 func validateAndUnmarshalSequenceAction(tx *sequencerblockv1.RollupData) (*types.Transaction, error) {
+	return unmarshalSequencedTx(tx.GetSequencedData())
+}
+
+// unmarshalSequencedTx unmarshals a transaction sent through the sequencer, rejecting the
+// transaction types which cannot be sequenced.
+func unmarshalSequencedTx(data []byte) (*types.Transaction, error) {
 	ethTx := new(types.Transaction)
-	err := ethTx.UnmarshalBinary(tx.GetSequencedData())
+	err := ethTx.UnmarshalBinary(data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal sequenced data into transaction: %w. tx hash: %s", err, sha256.Sum256(tx.GetSequencedData()))
+		return nil, fmt.Errorf("failed to unmarshal sequenced data into transaction: %w. tx hash: %s", err, sha256.Sum256(data))
 	}
 
 	if ethTx.Type() == types.DepositTxType {
-		return nil, fmt.Errorf("deposit tx not allowed in sequenced data. tx hash: %s", sha256.Sum256(tx.GetSequencedData()))
+		return nil, fmt.Errorf("deposit tx not allowed in sequenced data. tx hash: %s", sha256.Sum256(data))
 	}
 
 	if ethTx.Type() == types.BlobTxType {
-		return nil, fmt.Errorf("blob tx not allowed in sequenced data. tx hash: %s", sha256.Sum256(tx.GetSequencedData()))
+		return nil, fmt.Errorf("blob tx not allowed in sequenced data. tx hash: %s", sha256.Sum256(data))
 	}
 
 	return ethTx, nil
 }
 
-func unmarshalAllocationTxs(allocation *auctionv1alpha1.Allocation, prevBlockHash []byte, auctioneerBech32Address string, addressPrefix string) (types.Transactions, error) {
+func unmarshalAllocationTxs(allocation *auctionv1alpha1.Allocation, prevBlockHash []byte, auctioneerBech32Addresses []string, addressPrefix string) (types.Transactions, error) {
 	unbundlingStart := time.Now()
 	defer allocationUnbundlingTimer.UpdateSince(unbundlingStart)
 
@@ -146,7 +306,7 @@ func unmarshalAllocationTxs(allocation *auctionv1alpha1.Allocation, prevBlockHas
 		return nil, WrapError(err, "failed to unmarshal bid")
 	}
 
-	log.Debug("Found a potential allocation in the rollup data. Checking if it is valid.", "prevBlockHash", common.BytesToHash(prevBlockHash).String(), "auctioneerBech32Address", auctioneerBech32Address)
+	log.Debug("Found a potential allocation in the rollup data. Checking if it is valid.", "prevBlockHash", common.BytesToHash(prevBlockHash).String(), "auctioneerBech32Addresses", auctioneerBech32Addresses)
 
 	if !bytes.Equal(bid.GetRollupParentBlockHash(), prevBlockHash) {
 		allocationsWithInvalidPrevBlockHash.Inc(1)
@@ -159,9 +319,9 @@ func unmarshalAllocationTxs(allocation *auctionv1alpha1.Allocation, prevBlockHas
 		return nil, WrapError(err, fmt.Sprintf("failed to encode public key to bech32m address: %s", publicKey))
 	}
 
-	if auctioneerBech32Address != bech32Address {
+	if !slices.Contains(auctioneerBech32Addresses, bech32Address) {
 		allocationsWithInvalidPubKey.Inc(1)
-		return nil, fmt.Errorf("address in allocation does not match auctioneer address. expected: %s, got: %s", auctioneerBech32Address, bech32Address)
+		return nil, fmt.Errorf("address in allocation does not match auctioneer address. expected one of: %v, got: %s", auctioneerBech32Addresses, bech32Address)
 	}
 
 	message, err := proto.Marshal(bid)
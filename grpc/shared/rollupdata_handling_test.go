@@ -8,6 +8,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"math/big"
+	"strings"
 	"testing"
 
 	primitivev1 "buf.build/gen/go/astria/primitives/protocolbuffers/go/astria/primitive/v1"
@@ -194,7 +195,7 @@ func TestUnmarshallAllocationTxs(t *testing.T) {
 			allocation, err := test.allocationInfo.convertToAllocation()
 			require.NoError(t, err, "failed to convert allocation info to allocation: %v", err)
 
-			finalTxs, err := unmarshalAllocationTxs(allocation, test.prevBlockHash, serviceV1Alpha1.AuctioneerAddress(), addressPrefix)
+			finalTxs, err := unmarshalAllocationTxs(allocation, test.prevBlockHash, serviceV1Alpha1.AuctioneerAddresses(2), addressPrefix)
 			if test.wantErr == "" && err == nil {
 				for _, tx := range test.expectedOutput {
 					foundTx := false
@@ -474,7 +475,8 @@ func TestUnbundleRollupData(t *testing.T) {
 
 	finalTxs := []*sequencerblockv1.RollupData{seqData1, seqData2, allocationSequenceData, depositTx}
 
-	txsToProcess := serviceV1Alpha1.UnbundleRollupDataTransactions(finalTxs, 2, prevRollupBlockHash)
+	txsToProcess, _, err := serviceV1Alpha1.UnbundleRollupDataTransactions(finalTxs, 2, prevRollupBlockHash)
+	require.NoError(t, err)
 
 	require.Equal(t, txsToProcess.Len(), 6, "expected 6 txs to process")
 
@@ -580,7 +582,8 @@ func TestUnbundleRollupDataWithDuplicateAllocations(t *testing.T) {
 
 	finalTxs := []*sequencerblockv1.RollupData{seqData1, seqData2, allocationSequenceData, allocationSequenceData2, depositTx}
 
-	txsToProcess := serviceV1Alpha1.UnbundleRollupDataTransactions(finalTxs, 2, prevRollupBlockHash)
+	txsToProcess, _, err := serviceV1Alpha1.UnbundleRollupDataTransactions(finalTxs, 2, prevRollupBlockHash)
+	require.NoError(t, err)
 
 	require.Equal(t, txsToProcess.Len(), 6, "expected 6 txs to process")
 
@@ -724,7 +727,8 @@ func TestUnbundleRollupDataWithDuplicateInvalidAllocations(t *testing.T) {
 
 	finalTxs := []*sequencerblockv1.RollupData{seqData1, seqData2, allocationSequenceData, invalidAllocationSequenceData, depositTx}
 
-	txsToProcess := serviceV1Alpha1.UnbundleRollupDataTransactions(finalTxs, 2, prevRollupBlockHash)
+	txsToProcess, _, err := serviceV1Alpha1.UnbundleRollupDataTransactions(finalTxs, 2, prevRollupBlockHash)
+	require.NoError(t, err)
 
 	require.Equal(t, txsToProcess.Len(), 6, "expected 6 txs to process")
 
@@ -735,3 +739,416 @@ func TestUnbundleRollupDataWithDuplicateInvalidAllocations(t *testing.T) {
 	require.True(t, bytes.Equal(txsToProcess[3].Hash().Bytes(), tx4.Hash().Bytes()), "expected tx4 to be fourth")
 	require.True(t, bytes.Equal(txsToProcess[4].Hash().Bytes(), tx5.Hash().Bytes()), "expected tx5 to be fifth")
 }
+
+func TestValidateAndUnmarshallErc20DepositTx(t *testing.T) {
+	_, serviceV1Alpha1, _, _ := SetupSharedService(t, 10)
+
+	chainDestinationAddress := TestToAddress
+	failedMintRecipient := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	erc20BridgeAddress := generateBech32MAddress()
+	erc20AssetDenom := "erc20-asset-denom"
+
+	tests := []struct {
+		description  string
+		erc20Asset   *params.AstriaErc20AssetConfig
+		wantGas      uint64
+		wantFallback bool
+	}{
+		{
+			description: "default mint gas limit and no fallback",
+			erc20Asset: &params.AstriaErc20AssetConfig{
+				ContractAddress:   common.HexToAddress("0x00000000000000000000000000000000000000bb"),
+				ContractPrecision: 18,
+			},
+			wantGas:      params.DefaultAstriaErc20MintGasLimit,
+			wantFallback: false,
+		},
+		{
+			description: "configured mint gas limit and fallback",
+			erc20Asset: &params.AstriaErc20AssetConfig{
+				ContractAddress:     common.HexToAddress("0x00000000000000000000000000000000000000bb"),
+				ContractPrecision:   18,
+				MintGasLimit:        100000,
+				FailedMintRecipient: &failedMintRecipient,
+			},
+			wantGas:      100000,
+			wantFallback: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			serviceV1Alpha1.BridgeAddresses()[erc20BridgeAddress] = &params.AstriaBridgeAddressConfig{
+				BridgeAddress:  erc20BridgeAddress,
+				SenderAddress:  common.HexToAddress("0x00000000000000000000000000000000000000cc"),
+				StartHeight:    1,
+				AssetDenom:     erc20AssetDenom,
+				AssetPrecision: 18,
+				Erc20Asset:     test.erc20Asset,
+			}
+			serviceV1Alpha1.BridgeAllowedAssets()[erc20AssetDenom] = struct{}{}
+
+			deposit := &sequencerblockv1.Deposit{
+				BridgeAddress: &primitivev1.Address{
+					Bech32M: erc20BridgeAddress,
+				},
+				Asset:                   erc20AssetDenom,
+				Amount:                  BigIntToProtoU128(big.NewInt(1000000000000000000)),
+				RollupId:                &primitivev1.RollupId{Inner: make([]byte, 0)},
+				DestinationChainAddress: chainDestinationAddress.String(),
+				SourceTransactionId: &primitivev1.TransactionId{
+					Inner: "test_tx_hash",
+				},
+				SourceActionIndex: 0,
+			}
+
+			tx, err := validateAndUnmarshalDepositTx(deposit, 2, serviceV1Alpha1.BridgeAddresses(), serviceV1Alpha1.BridgeAllowedAssets())
+			require.NoError(t, err, "failed to validate erc20 deposit tx")
+			require.Equal(t, test.wantGas, tx.Gas(), "unexpected mint gas limit")
+			require.Equal(t, test.erc20Asset.ContractAddress, *tx.To(), "deposit tx should call the erc20 contract")
+			require.Equal(t, test.wantFallback, len(tx.DepositFallbackData()) > 0, "unexpected fallback data")
+			if test.wantFallback {
+				require.True(t, bytes.Contains(tx.DepositFallbackData(), failedMintRecipient.Bytes()), "fallback should mint to the failed mint recipient")
+			}
+		})
+	}
+}
+
+func TestValidateAndUnmarshallDepositCallTx(t *testing.T) {
+	ethservice, serviceV1Alpha1, _, _ := SetupSharedService(t, 10)
+
+	bridgeAddress := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].BridgeAddress
+	bridgeAssetDenom := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].AssetDenom
+	allowedTarget := common.HexToAddress("0x00000000000000000000000000000000000000ee")
+	disallowedTarget := common.HexToAddress("0x00000000000000000000000000000000000000ff")
+	serviceV1Alpha1.BridgeAddresses()[bridgeAddress].DepositCall = &params.AstriaDepositCallConfig{
+		AllowedTargets: []common.Address{allowedTarget},
+		GasLimit:       200000,
+	}
+	defer func() { serviceV1Alpha1.BridgeAddresses()[bridgeAddress].DepositCall = nil }()
+
+	tests := []struct {
+		description string
+		destination string
+		wantTo      common.Address
+		wantData    []byte
+	}{
+		{
+			description: "allowed target is called",
+			destination: TestToAddress.Hex() + ":" + allowedTarget.Hex() + ":0x01020304",
+			wantTo:      allowedTarget,
+			wantData:    []byte{0x01, 0x02, 0x03, 0x04},
+		},
+		{
+			description: "disallowed target mints to recipient",
+			destination: TestToAddress.Hex() + ":" + disallowedTarget.Hex() + ":0x01020304",
+			wantTo:      TestToAddress,
+		},
+		{
+			description: "empty calldata mints to recipient",
+			destination: TestToAddress.Hex() + ":" + allowedTarget.Hex() + ":0x",
+			wantTo:      TestToAddress,
+		},
+		{
+			description: "missing calldata mints to the destination",
+			destination: TestToAddress.Hex() + ":" + allowedTarget.Hex(),
+			wantTo:      common.HexToAddress(TestToAddress.Hex() + ":" + allowedTarget.Hex()),
+		},
+		{
+			description: "invalid target mints to recipient",
+			destination: TestToAddress.Hex() + ":not-an-address:0x01",
+			wantTo:      TestToAddress,
+		},
+		{
+			description: "invalid calldata mints to recipient",
+			destination: TestToAddress.Hex() + ":" + allowedTarget.Hex() + ":0xzz",
+			wantTo:      TestToAddress,
+		},
+	}
+
+	bridgeSender := serviceV1Alpha1.BridgeAddresses()[bridgeAddress].SenderAddress
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			deposit := depositRollupData(bridgeAddress, bridgeAssetDenom, 100, 0).GetDeposit()
+			deposit.DestinationChainAddress = test.destination
+
+			tx, err := validateAndUnmarshalDepositTx(deposit, 2, serviceV1Alpha1.BridgeAddresses(), serviceV1Alpha1.BridgeAllowedAssets())
+			require.NoError(t, err, "failed to validate deposit call tx")
+			require.Equal(t, test.wantTo, *tx.To(), "unexpected deposit tx target")
+			require.True(t, bytes.Equal(test.wantData, tx.Data()), "unexpected deposit tx calldata")
+			if len(test.wantData) > 0 {
+				require.Equal(t, uint64(200000), tx.Gas(), "deposit call should use the configured gas limit")
+				require.Equal(t, TestToAddress, tx.DepositRefundRecipient(), "deposit call should refund the recipient")
+				require.NotEqual(t, bridgeSender, depositSender(t, tx), "deposit call should not be sent from the bridge sender")
+			} else {
+				require.Equal(t, bridgeSender, depositSender(t, tx), "deposit mint should be sent from the bridge sender")
+			}
+		})
+	}
+
+	// every deposit call is sent from its own address
+	destination := TestToAddress.Hex() + ":" + allowedTarget.Hex() + ":0x01"
+	senders := make(map[common.Address]struct{})
+	for _, data := range []*sequencerblockv1.RollupData{
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 100, 0),
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 100, 1),
+		depositRollupData(bridgeAddress, bridgeAssetDenom, 100, 0),
+	} {
+		deposit := data.GetDeposit()
+		deposit.DestinationChainAddress = destination
+		if len(senders) == 2 {
+			deposit.SourceTransactionId.Inner = "other_tx_hash"
+		}
+		tx, err := validateAndUnmarshalDepositTx(deposit, 2, serviceV1Alpha1.BridgeAddresses(), serviceV1Alpha1.BridgeAllowedAssets())
+		require.NoError(t, err, "failed to validate deposit call tx")
+		senders[depositSender(t, tx)] = struct{}{}
+	}
+	require.Len(t, senders, 3, "deposit calls should be sent from distinct addresses")
+}
+
+func TestValidateAndUnmarshallDepositTxInvalidRecipient(t *testing.T) {
+	ethservice, serviceV1Alpha1, _, _ := SetupSharedService(t, 10)
+
+	bridgeAddress := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].BridgeAddress
+	bridgeAssetDenom := ethservice.BlockChain().Config().AstriaBridgeAddressConfigs[0].AssetDenom
+	fallback := common.HexToAddress("0x00000000000000000000000000000000000000fa")
+	bac := serviceV1Alpha1.BridgeAddresses()[bridgeAddress]
+	defer func() {
+		bac.StrictRecipientHeight = 0
+		bac.InvalidRecipientFallback = nil
+		bac.RequireChecksummedRecipient = false
+	}()
+
+	checksummed := TestToAddress.Hex()
+	lowercase := strings.ToLower(checksummed)
+	// flip the case of the first letter to break the checksum
+	badChecksum := []byte(checksummed)
+	for i := 2; i < len(badChecksum); i++ {
+		if c := badChecksum[i]; c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F' {
+			badChecksum[i] ^= 0x20
+			break
+		}
+	}
+
+	tests := []struct {
+		description     string
+		destination     string
+		height          uint64
+		fallback        *common.Address
+		requireChecksum bool
+		wantRecipient   common.Address
+		wantErr         string
+	}{
+		{
+			description:   "before strict recipient height malformed addresses are parsed leniently",
+			destination:   "0x1234",
+			height:        2,
+			fallback:      &fallback,
+			wantRecipient: common.HexToAddress("0x1234"),
+		},
+		{
+			description: "without fallback malformed addresses are rejected",
+			destination: "0x1234",
+			wantErr:     "invalid deposit recipient",
+		},
+		{
+			description:   "without fallback valid addresses are accepted",
+			destination:   checksummed,
+			wantRecipient: TestToAddress,
+		},
+		{
+			description:   "checksummed address",
+			destination:   checksummed,
+			fallback:      &fallback,
+			wantRecipient: TestToAddress,
+		},
+		{
+			description:   "lowercase address without prefix",
+			destination:   lowercase[2:],
+			fallback:      &fallback,
+			wantRecipient: TestToAddress,
+		},
+		{
+			description:   "too short address",
+			destination:   "0x1234",
+			fallback:      &fallback,
+			wantRecipient: fallback,
+		},
+		{
+			description:   "too long address",
+			destination:   checksummed + "00",
+			fallback:      &fallback,
+			wantRecipient: fallback,
+		},
+		{
+			description:   "double prefixed address",
+			destination:   "0x0X" + lowercase[4:],
+			fallback:      &fallback,
+			wantRecipient: fallback,
+		},
+		{
+			description:   "non hex address",
+			destination:   "0x" + strings.Repeat("zz", common.AddressLength),
+			fallback:      &fallback,
+			wantRecipient: fallback,
+		},
+		{
+			description:   "empty address",
+			destination:   "",
+			fallback:      &fallback,
+			wantRecipient: fallback,
+		},
+		{
+			description:   "invalid checksum",
+			destination:   string(badChecksum),
+			fallback:      &fallback,
+			wantRecipient: fallback,
+		},
+		{
+			description:     "lowercase address when checksum is required",
+			destination:     lowercase,
+			fallback:        &fallback,
+			requireChecksum: true,
+			wantRecipient:   fallback,
+		},
+		{
+			description:     "checksummed address when checksum is required",
+			destination:     checksummed,
+			fallback:        &fallback,
+			requireChecksum: true,
+			wantRecipient:   TestToAddress,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			bac.StrictRecipientHeight = 3
+			bac.InvalidRecipientFallback = test.fallback
+			bac.RequireChecksummedRecipient = test.requireChecksum
+			height := test.height
+			if height == 0 {
+				height = bac.StrictRecipientHeight
+			}
+
+			deposit := depositRollupData(bridgeAddress, bridgeAssetDenom, 100, 0).GetDeposit()
+			deposit.DestinationChainAddress = test.destination
+
+			tx, err := validateAndUnmarshalDepositTx(deposit, height, serviceV1Alpha1.BridgeAddresses(), serviceV1Alpha1.BridgeAllowedAssets())
+			if test.wantErr != "" {
+				require.ErrorContains(t, err, test.wantErr, "expected deposit to be rejected")
+				return
+			}
+			require.NoError(t, err, "failed to validate deposit tx")
+			require.Equal(t, test.wantRecipient, *tx.To(), "unexpected deposit recipient")
+		})
+	}
+}
+
+func TestUnbundleRollupDataAuctioneerRotation(t *testing.T) {
+	ethservice, serviceV1Alpha1, oldPrivKey, oldPubKey := SetupSharedService(t, 10)
+	config := ethservice.BlockChain().Config()
+
+	newPubKey, newPrivKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err, "failed to generate auctioneer key")
+	newAddress, err := EncodeFromPublicKey(config.AstriaSequencerAddressPrefix, newPubKey)
+	require.NoError(t, err, "failed to encode auctioneer address")
+
+	originalAddresses := config.AstriaAuctioneerAddresses
+	config.AstriaAuctioneerAddresses = map[uint32]string{
+		1:  originalAddresses[1],
+		20: newAddress,
+		30: "",
+	}
+	config.AstriaAuctioneerOverlapBlocks = 2
+	defer func() {
+		config.AstriaAuctioneerAddresses = originalAddresses
+		config.AstriaAuctioneerOverlapBlocks = 0
+	}()
+
+	prevRollupBlockHash := []byte("prev rollup block hash")
+	allocationTx := transaction(0, 1000, TestKey)
+	seqTx := transaction(1, 1000, TestKey)
+	marshalledSeqTx, err := seqTx.MarshalBinary()
+	require.NoError(t, err, "failed to marshal tx")
+
+	oldAllocation := signedAllocation(t, oldPrivKey, oldPubKey, prevRollupBlockHash, allocationTx)
+	newAllocation := signedAllocation(t, newPrivKey, newPubKey, prevRollupBlockHash, allocationTx)
+
+	tests := []struct {
+		description string
+		allocation  []byte
+		height      uint64
+		accepted    bool
+	}{
+		{description: "old key before rotation", allocation: oldAllocation, height: 19, accepted: true},
+		{description: "new key before rotation", allocation: newAllocation, height: 19, accepted: false},
+		{description: "old key within overlap", allocation: oldAllocation, height: 21, accepted: true},
+		{description: "new key within overlap", allocation: newAllocation, height: 20, accepted: true},
+		{description: "old key after overlap", allocation: oldAllocation, height: 22, accepted: false},
+		{description: "new key after overlap", allocation: newAllocation, height: 22, accepted: true},
+		{description: "new key after auctions are disabled", allocation: newAllocation, height: 30, accepted: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			txs, _, err := serviceV1Alpha1.UnbundleRollupDataTransactions([]*sequencerblockv1.RollupData{
+				{Value: &sequencerblockv1.RollupData_SequencedData{SequencedData: test.allocation}},
+				{Value: &sequencerblockv1.RollupData_SequencedData{SequencedData: marshalledSeqTx}},
+			}, test.height, prevRollupBlockHash)
+			require.NoError(t, err)
+
+			if test.accepted {
+				requireTxHashes(t, types.Transactions{allocationTx, seqTx}, txs)
+			} else {
+				requireTxHashes(t, types.Transactions{seqTx}, txs)
+			}
+		})
+	}
+}
+
+func TestUnbundleRollupDataDepositsFirst(t *testing.T) {
+	ethservice, serviceV1Alpha1, _, _ := SetupSharedService(t, 10)
+	config := ethservice.BlockChain().Config()
+
+	tx1 := transaction(0, 1000, TestKey)
+	tx2 := transaction(1, 1000, TestKey)
+	marshalledTx1, err := tx1.MarshalBinary()
+	require.NoError(t, err, "failed to marshal valid tx")
+	marshalledTx2, err := tx2.MarshalBinary()
+	require.NoError(t, err, "failed to marshal valid tx")
+
+	bridgeConfig := config.AstriaBridgeAddressConfigs[0]
+	depositTx := &sequencerblockv1.RollupData{Value: &sequencerblockv1.RollupData_Deposit{Deposit: &sequencerblockv1.Deposit{
+		BridgeAddress:           &primitivev1.Address{Bech32M: bridgeConfig.BridgeAddress},
+		Asset:                   bridgeConfig.AssetDenom,
+		Amount:                  BigIntToProtoU128(big.NewInt(1000000000000000000)),
+		RollupId:                &primitivev1.RollupId{Inner: make([]byte, 0)},
+		DestinationChainAddress: crypto.PubkeyToAddress(TestKey.PublicKey).String(),
+		SourceTransactionId:     &primitivev1.TransactionId{Inner: "test_tx_hash"},
+		SourceActionIndex:       0,
+	}}}
+	rollupData := []*sequencerblockv1.RollupData{
+		{Value: &sequencerblockv1.RollupData_SequencedData{SequencedData: marshalledTx1}},
+		depositTx,
+		{Value: &sequencerblockv1.RollupData_SequencedData{SequencedData: marshalledTx2}},
+	}
+
+	// without gas reserved for deposits, the sequencer order is kept
+	txs, _, err := serviceV1Alpha1.UnbundleRollupDataTransactions(rollupData, 2, []byte("prev rollup block hash"))
+	require.NoError(t, err)
+	require.Len(t, txs, 3, "expected 3 txs to process")
+	require.Equal(t, tx1.Hash(), txs[0].Hash(), "expected tx1 to be first")
+	require.Equal(t, uint8(types.DepositTxType), txs[1].Type(), "expected deposit to be second")
+	require.Equal(t, tx2.Hash(), txs[2].Hash(), "expected tx2 to be third")
+
+	// with gas reserved for deposits, deposits are moved ahead of the sequenced txs
+	config.AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+		1: {ElasticityMultiplier: 2, BaseFeeChangeDenominator: 8, GasLimit: 30_000_000, DepositGasLimit: 1_000_000},
+	})
+	txs, _, err = serviceV1Alpha1.UnbundleRollupDataTransactions(rollupData, 2, []byte("prev rollup block hash"))
+	require.NoError(t, err)
+	require.Len(t, txs, 3, "expected 3 txs to process")
+	require.Equal(t, uint8(types.DepositTxType), txs[0].Type(), "expected deposit to be first")
+	require.Equal(t, tx1.Hash(), txs[1].Hash(), "expected tx1 to be second")
+	require.Equal(t, tx2.Hash(), txs[2].Hash(), "expected tx2 to be third")
+}
@@ -19,7 +19,7 @@ func SetupSharedService(t *testing.T, noOfBlocksToGenerate int) (*eth.Ethereum,
 	require.Nil(t, err, "can't create shared service")
 
 	feeCollector := crypto.PubkeyToAddress(feeCollectorKey.PublicKey)
-	require.Equal(t, feeCollector, sharedService.NextFeeRecipient(), "nextFeeRecipient not set correctly")
+	require.Equal(t, feeCollector, sharedService.FeeRecipient(ethservice.BlockChain().CurrentBlock().Number.Uint64()+1), "fee recipient not set correctly")
 
 	bridgeAsset := genesis.Config.AstriaBridgeAddressConfigs[0].AssetDenom
 	_, ok := sharedService.BridgeAllowedAssets()[bridgeAsset]
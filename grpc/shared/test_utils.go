@@ -82,7 +82,7 @@ func GenerateMergeChain(n int, merged bool) (*core.Genesis, []*types.Block, stri
 		{
 			BridgeAddress:  bech32mBridgeAddress,
 			SenderAddress:  common.Address{},
-			StartHeight:    2,
+			StartHeight:    1,
 			AssetDenom:     "nria",
 			AssetPrecision: 18,
 			Erc20Asset:     nil,
@@ -132,6 +132,12 @@ func GenerateMergeChain(n int, merged bool) (*core.Genesis, []*types.Block, stri
 
 // startEthService creates a full node instance for testing.
 func StartEthService(t *testing.T, genesis *core.Genesis) *eth.Ethereum {
+	return StartEthServiceWithConfig(t, genesis, nil)
+}
+
+// StartEthServiceWithConfig creates a full node instance for testing, letting the caller
+// modify the eth config of the node before it is started.
+func StartEthServiceWithConfig(t *testing.T, genesis *core.Genesis, configure func(*ethconfig.Config)) *eth.Ethereum {
 	n, err := node.New(&node.Config{
 		EnableAuctioneer: true,
 	})
@@ -139,6 +145,9 @@ func StartEthService(t *testing.T, genesis *core.Genesis) *eth.Ethereum {
 	mcfg := miner.DefaultConfig
 	mcfg.PendingFeeRecipient = TestAddr
 	ethcfg := &ethconfig.Config{Genesis: genesis, SyncMode: downloader.FullSync, TrieTimeout: time.Minute, TrieDirtyCache: 256, TrieCleanCache: 256, Miner: mcfg}
+	if configure != nil {
+		configure(ethcfg)
+	}
 	ethservice, err := eth.New(n, ethcfg)
 	require.Nil(t, err, "can't create eth service")
 
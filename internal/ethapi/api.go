@@ -963,7 +963,7 @@ func (s *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rpc.
 
 	result := make([]map[string]interface{}, len(receipts))
 	for i, receipt := range receipts {
-		result[i] = marshalReceipt(receipt, block.Hash(), block.NumberU64(), signer, txs[i], i)
+		result[i] = marshalReceipt(receipt, block.Hash(), block.NumberU64(), signer, s.b.ChainConfig(), txs[i], i)
 	}
 
 	return result, nil
@@ -1350,6 +1350,12 @@ type RPCTransaction struct {
 	R                   *hexutil.Big      `json:"r"`
 	S                   *hexutil.Big      `json:"s"`
 	YParity             *hexutil.Uint64   `json:"yParity,omitempty"`
+
+	// Astria deposit transaction fields
+	SourceTransactionId    *string         `json:"sourceTransactionId,omitempty"`
+	SourceTransactionIndex *hexutil.Uint64 `json:"sourceTransactionIndex,omitempty"`
+	FallbackData           *hexutil.Bytes  `json:"fallbackData,omitempty"`
+	RefundRecipient        *common.Address `json:"refundRecipient,omitempty"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -1424,6 +1430,20 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		}
 		result.MaxFeePerBlobGas = (*hexutil.Big)(tx.BlobGasFeeCap())
 		result.BlobVersionedHashes = tx.BlobHashes()
+
+	case types.DepositTxType:
+		// deposits are not signed, the sender is the bridge sender set in the genesis
+		result.From = tx.From()
+		sourceTransactionId := tx.DepositSourceTransactionId()
+		sourceTransactionIndex := hexutil.Uint64(tx.DepositSourceTransactionIndex())
+		result.SourceTransactionId = &sourceTransactionId
+		result.SourceTransactionIndex = &sourceTransactionIndex
+		if fallbackData := tx.DepositFallbackData(); len(fallbackData) > 0 {
+			result.FallbackData = (*hexutil.Bytes)(&fallbackData)
+		}
+		if refundRecipient := tx.DepositRefundRecipient(); refundRecipient != (common.Address{}) {
+			result.RefundRecipient = &refundRecipient
+		}
 	}
 	return result
 }
@@ -1704,12 +1724,15 @@ func (s *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash common.
 
 	// Derive the sender.
 	signer := types.MakeSigner(s.b.ChainConfig(), header.Number, header.Time)
-	return marshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index)), nil
+	return marshalReceipt(receipt, blockHash, blockNumber, signer, s.b.ChainConfig(), tx, int(index)), nil
 }
 
 // marshalReceipt marshals a transaction receipt into a JSON object.
-func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber uint64, signer types.Signer, tx *types.Transaction, txIndex int) map[string]interface{} {
+func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber uint64, signer types.Signer, config *params.ChainConfig, tx *types.Transaction, txIndex int) map[string]interface{} {
 	from, _ := types.Sender(signer, tx)
+	if tx.Type() == types.DepositTxType {
+		from = tx.From()
+	}
 
 	fields := map[string]interface{}{
 		"blockHash":         blockHash,
@@ -1741,6 +1764,11 @@ func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber u
 		fields["blobGasUsed"] = hexutil.Uint64(receipt.BlobGasUsed)
 		fields["blobGasPrice"] = (*hexutil.Big)(receipt.BlobGasPrice)
 	}
+	if tx.Type() == types.DepositTxType {
+		if deposit := marshalDepositReceipt(receipt, blockNumber, config, tx); deposit != nil {
+			fields["astriaDeposit"] = deposit
+		}
+	}
 
 	// If the ContractAddress is 20 0x0 bytes, assume it is not a contract creation
 	if receipt.ContractAddress != (common.Address{}) {
@@ -2231,13 +2259,21 @@ func checkTxFee(gasPrice *big.Int, gas uint64, cap float64) error {
 	return nil
 }
 
+// checkTxBaseFee is an internal function used to check whether the fee cap of the
+// transaction covers the minimum base fee, and its effective tip at that base fee the
+// minimum priority fee, so it is not rejected by the chain.
 func checkTxBaseFee(chainConfig *params.ChainConfig, blockNum uint64, tx *types.Transaction) error {
 	if chainConfig.AstriaEIP1559Params == nil {
 		return nil
 	}
 
 	baseFee := chainConfig.AstriaEIP1559Params.MinBaseFeeAt(blockNum)
-	_, err := tx.EffectiveGasTip(baseFee)
-
-	return err
+	tip, err := tx.EffectiveGasTip(baseFee)
+	if err != nil {
+		return err
+	}
+	if minTip := chainConfig.AstriaMinPriorityFee(blockNum); tip.Cmp(minTip) < 0 {
+		return fmt.Errorf("%w: effective tip %v, minimum %v", core.ErrTipBelowMinimum, tip, minTip)
+	}
+	return nil
 }
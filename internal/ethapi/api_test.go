@@ -32,6 +32,7 @@ import (
 	"testing"
 	"time"
 
+	primitivev1 "buf.build/gen/go/astria/primitives/protocolbuffers/go/astria/primitive/v1"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 
@@ -1931,6 +1932,252 @@ func TestRPCGetTransactionReceipt(t *testing.T) {
 	}
 }
 
+func setupDepositBackend(t *testing.T) (*testBackend, []common.Hash) {
+	config := *params.MergedTestChainConfig
+	var (
+		bridge    = common.HexToAddress("0x00000000000000000000000000000000000b71d9")
+		recipient = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+		refund    = common.HexToAddress("0x000000000000000000000000000000000000bbbb")
+		genesis   = &core.Genesis{
+			Config:        &config,
+			ExcessBlobGas: new(uint64),
+			BlobGasUsed:   new(uint64),
+			Alloc:         types.GenesisAlloc{},
+		}
+		txs = []*types.Transaction{
+			types.NewTx(&types.DepositTx{
+				From:                   bridge,
+				Value:                  big.NewInt(params.Ether),
+				Gas:                    params.TxGas,
+				To:                     &recipient,
+				SourceTransactionId:    primitivev1.TransactionId{Inner: "5d2ad6a0c1a2e8ee5b8a8f4d3d0d3c2bbbd3b6c2a8c1e6d1e4d2c0a7c1f3f0a9"},
+				SourceTransactionIndex: 1,
+			}),
+			types.NewTx(&types.DepositTx{
+				From:                   bridge,
+				Value:                  big.NewInt(params.Ether),
+				Gas:                    50000,
+				To:                     &recipient,
+				Data:                   common.FromHex("0xd0e30db0"),
+				SourceTransactionId:    primitivev1.TransactionId{Inner: "0b1d4c3a6f2e8d9c0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f6071"},
+				SourceTransactionIndex: 0,
+				RefundRecipient:        refund,
+			}),
+		}
+	)
+	config.AstriaBridgeAddressConfigs = []params.AstriaBridgeAddressConfig{
+		{
+			BridgeAddress:  "astria1kuwsjqqqqqqqqqqqqqqqqqqqqqqqqqqqmw0439",
+			SenderAddress:  bridge,
+			StartHeight:    1,
+			AssetDenom:     "nria",
+			AssetPrecision: 9,
+		},
+	}
+	backend := newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+		for _, tx := range txs {
+			b.AddTx(tx)
+		}
+	})
+	return backend, []common.Hash{txs[0].Hash(), txs[1].Hash()}
+}
+
+func TestRPCGetDepositTransaction(t *testing.T) {
+	t.Parallel()
+
+	var (
+		backend, txHashes = setupDepositBackend(t)
+		api               = NewTransactionAPI(backend, new(AddrLocker))
+	)
+
+	var testSuite = []struct {
+		txHash common.Hash
+		file   string
+	}{
+		// 0. native asset deposit
+		{
+			txHash: txHashes[0],
+			file:   "deposit-tx",
+		},
+		// 1. native asset deposit call with a refund recipient
+		{
+			txHash: txHashes[1],
+			file:   "deposit-tx-with-refund-recipient",
+		},
+	}
+
+	for i, tt := range testSuite {
+		result, err := api.GetTransactionByHash(context.Background(), tt.txHash)
+		if err != nil {
+			t.Errorf("test %d: want no error, have %v", i, err)
+			continue
+		}
+		testRPCResponseWithFile(t, i, result, "eth_getTransactionByHash", tt.file)
+
+		// the response must decode back into the same transaction
+		data, err := json.Marshal(result)
+		if err != nil {
+			t.Fatalf("test %d: json marshal error: %v", i, err)
+		}
+		tx := new(types.Transaction)
+		if err := json.Unmarshal(data, tx); err != nil {
+			t.Fatalf("test %d: json unmarshal error: %v", i, err)
+		}
+		if tx.Hash() != tt.txHash {
+			t.Errorf("test %d: decoded hash mismatch, want %v, have %v", i, tt.txHash, tx.Hash())
+		}
+	}
+}
+
+func TestRPCGetDepositTransactionReceipt(t *testing.T) {
+	t.Parallel()
+
+	var (
+		backend, txHashes = setupDepositBackend(t)
+		api               = NewTransactionAPI(backend, new(AddrLocker))
+	)
+
+	var testSuite = []struct {
+		txHash common.Hash
+		file   string
+	}{
+		// 0. native asset deposit
+		{
+			txHash: txHashes[0],
+			file:   "deposit-tx",
+		},
+		// 1. native asset deposit call with a refund recipient
+		{
+			txHash: txHashes[1],
+			file:   "deposit-tx-with-refund-recipient",
+		},
+	}
+
+	for i, tt := range testSuite {
+		result, err := api.GetTransactionReceipt(context.Background(), tt.txHash)
+		if err != nil {
+			t.Errorf("test %d: want no error, have %v", i, err)
+			continue
+		}
+		testRPCResponseWithFile(t, i, result, "eth_getTransactionReceipt", tt.file)
+	}
+}
+
+func TestMarshalDepositReceipt(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender    = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		erc20     = common.HexToAddress("0x00000000000000000000000000000000000e2c20")
+		recipient = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+		fallback  = common.HexToAddress("0x000000000000000000000000000000000000fa11")
+		refund    = common.HexToAddress("0x000000000000000000000000000000000000bbbb")
+		config    = &params.ChainConfig{
+			AstriaBridgeAddressConfigs: []params.AstriaBridgeAddressConfig{
+				{BridgeAddress: "native", StartHeight: 1, AssetDenom: "nria", AssetPrecision: 9},
+				{
+					BridgeAddress:  "erc20",
+					StartHeight:    1,
+					AssetDenom:     "usdc",
+					AssetPrecision: 6,
+					SenderAddress:  sender,
+					Erc20Asset:     &params.AstriaErc20AssetConfig{ContractAddress: erc20, ContractPrecision: 18, FailedMintRecipient: &fallback},
+				},
+			},
+		}
+		scaled   = new(big.Int).Mul(big.NewInt(5), big.NewInt(1e12))
+		mintData = func(to common.Address) []byte {
+			data := append(common.FromHex("0x40c10f19"), common.LeftPadBytes(to.Bytes(), 32)...)
+			return append(data, common.LeftPadBytes(scaled.Bytes(), 32)...)
+		}
+		mintLog = func(to common.Address) *types.Log {
+			return &types.Log{Address: erc20, Topics: []common.Hash{{0x1}, common.BytesToHash(to.Bytes())}}
+		}
+		erc20Tx = types.NewTx(&types.DepositTx{
+			From:         sender,
+			To:           &erc20,
+			Value:        new(big.Int),
+			Data:         mintData(recipient),
+			FallbackData: mintData(fallback),
+		})
+		callTx = types.NewTx(&types.DepositTx{
+			To:              &recipient,
+			Value:           big.NewInt(params.GWei),
+			Data:            common.FromHex("0xd0e30db0"),
+			RefundRecipient: refund,
+		})
+	)
+	tests := []struct {
+		tx      *types.Transaction
+		receipt *types.Receipt
+		height  uint64
+		bridge  string
+		amount  *big.Int
+		status  string
+	}{
+		{
+			tx:      erc20Tx,
+			receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{mintLog(recipient)}},
+			height:  1,
+			bridge:  "erc20",
+			amount:  big.NewInt(5),
+			status:  DepositMinted,
+		},
+		{
+			tx:      erc20Tx,
+			receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{mintLog(fallback)}},
+			height:  1,
+			bridge:  "erc20",
+			amount:  big.NewInt(5),
+			status:  DepositMintedToFallback,
+		},
+		{
+			tx:      erc20Tx,
+			receipt: &types.Receipt{Status: types.ReceiptStatusFailed},
+			height:  1,
+			bridge:  "erc20",
+			amount:  big.NewInt(5),
+			status:  DepositFailed,
+		},
+		{
+			tx:      callTx,
+			receipt: &types.Receipt{Status: types.ReceiptStatusFailed},
+			height:  1,
+			bridge:  "native",
+			amount:  big.NewInt(1),
+			status:  DepositRefunded,
+		},
+		{
+			tx:      callTx,
+			receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful},
+			height:  0,
+		},
+	}
+	for i, tt := range tests {
+		deposit := marshalDepositReceipt(tt.receipt, tt.height, config, tt.tx)
+		if tt.bridge == "" {
+			if deposit != nil {
+				t.Errorf("test %d: expected no deposit details, got %+v", i, deposit)
+			}
+			continue
+		}
+		if deposit == nil {
+			t.Errorf("test %d: expected deposit details", i)
+			continue
+		}
+		if deposit.BridgeAddress != tt.bridge {
+			t.Errorf("test %d: expected bridge %q, got %q", i, tt.bridge, deposit.BridgeAddress)
+		}
+		if deposit.Amount.ToInt().Cmp(tt.amount) != 0 {
+			t.Errorf("test %d: expected amount %v, got %v", i, tt.amount, deposit.Amount.ToInt())
+		}
+		if deposit.MintStatus != tt.status {
+			t.Errorf("test %d: expected mint status %q, got %q", i, tt.status, deposit.MintStatus)
+		}
+	}
+}
+
 func TestRPCGetBlockReceipts(t *testing.T) {
 	t.Parallel()
 
@@ -2029,6 +2276,33 @@ func TestRPCGetBlockReceipts(t *testing.T) {
 	}
 }
 
+func TestCheckTxBaseFee(t *testing.T) {
+	config := *params.TestChainConfig
+	config.AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+		0:  {MinBaseFee: params.GWei, ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator},
+		10: {MinBaseFee: params.GWei, ElasticityMultiplier: params.DefaultElasticityMultiplier, BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator, MinPriorityFee: 2 * params.GWei},
+	})
+	tx := func(feeCap, tip int64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{GasFeeCap: big.NewInt(feeCap), GasTipCap: big.NewInt(tip)})
+	}
+	var tests = []struct {
+		blockNum uint64
+		tx       *types.Transaction
+		err      error
+	}{
+		{9, tx(params.GWei, 0), nil},
+		{9, tx(params.GWei-1, 0), types.ErrGasFeeCapTooLow},
+		{10, tx(3*params.GWei, 2*params.GWei), nil},
+		{10, tx(3*params.GWei, params.GWei), core.ErrTipBelowMinimum},
+		{10, tx(2*params.GWei, 2*params.GWei), core.ErrTipBelowMinimum}, // the effective tip is below the minimum
+	}
+	for i, tt := range tests {
+		if err := checkTxBaseFee(&config, tt.blockNum, tt.tx); !errors.Is(err, tt.err) {
+			t.Errorf("test %d: want error %v, have %v", i, tt.err, err)
+		}
+	}
+}
+
 func testRPCResponseWithFile(t *testing.T, testid int, result interface{}, rpc string, file string) {
 	data, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
@@ -0,0 +1,115 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Outcomes of the mint of a deposit transaction, as reported in its receipt.
+const (
+	// DepositMinted is reported when the deposit was credited to its recipient, or
+	// when the call of a deposit call succeeded.
+	DepositMinted = "minted"
+	// DepositMintedToFallback is reported when the ERC20 mint to the recipient failed
+	// and the tokens were minted to the failed mint recipient of the bridge instead.
+	DepositMintedToFallback = "mintedToFallback"
+	// DepositRefunded is reported when the call of a deposit call failed and the
+	// value was transferred to its refund recipient.
+	DepositRefunded = "refunded"
+	// DepositFailed is reported when the deposit was not credited to anyone.
+	DepositFailed = "failed"
+)
+
+// RPCDepositReceipt holds the bridge details of a deposit transaction receipt. They are
+// derived from the bridge config at the height of the block, and are not part of the
+// consensus encoding of the receipt.
+type RPCDepositReceipt struct {
+	BridgeAddress string       `json:"bridgeAddress"`
+	AssetDenom    string       `json:"assetDenom"`
+	Amount        *hexutil.Big `json:"amount"`
+	ScaledAmount  *hexutil.Big `json:"scaledAmount"`
+	MintStatus    string       `json:"mintStatus"`
+}
+
+// marshalDepositReceipt returns the bridge details of the receipt of the given deposit
+// transaction, or nil if no bridge configured at the block height created it.
+func marshalDepositReceipt(receipt *types.Receipt, blockNumber uint64, config *params.ChainConfig, tx *types.Transaction) *RPCDepositReceipt {
+	bridge := config.AstriaDepositBridge(blockNumber, tx.From(), tx.To())
+	if bridge == nil {
+		return nil
+	}
+	scaled := tx.Value()
+	if bridge.Erc20Asset != nil {
+		// The amount is the second argument of the `mint(address,uint256)` calldata.
+		data := tx.Data()
+		if len(data) < 4+2*32 {
+			return nil
+		}
+		scaled = new(big.Int).SetBytes(data[4+32 : 4+2*32])
+	}
+	return &RPCDepositReceipt{
+		BridgeAddress: bridge.BridgeAddress,
+		AssetDenom:    bridge.AssetDenom,
+		Amount:        (*hexutil.Big)(bridge.UnscaledDepositAmount(scaled)),
+		ScaledAmount:  (*hexutil.Big)(scaled),
+		MintStatus:    depositMintStatus(receipt, bridge, tx),
+	}
+}
+
+// depositMintStatus derives the outcome of the mint of a deposit from its receipt.
+func depositMintStatus(receipt *types.Receipt, bridge *params.AstriaBridgeAddressConfig, tx *types.Transaction) string {
+	if receipt.Status == types.ReceiptStatusFailed {
+		if tx.Value().Sign() > 0 && tx.DepositRefundRecipient() != (common.Address{}) {
+			return DepositRefunded
+		}
+		return DepositFailed
+	}
+	fallback := tx.DepositFallbackData()
+	if bridge.Erc20Asset == nil || len(fallback) < 4+32 || len(tx.Data()) < 4+32 {
+		return DepositMinted
+	}
+	// The fallback mint only runs if the mint to the recipient failed, which leaves no
+	// logs mentioning the recipient behind.
+	var (
+		recipient       = common.BytesToHash(tx.Data()[4 : 4+32])
+		fallbackAddress = common.BytesToHash(fallback[4 : 4+32])
+		mintedFallback  bool
+	)
+	for _, log := range receipt.Logs {
+		if log.Address != bridge.Erc20Asset.ContractAddress || len(log.Topics) == 0 {
+			continue
+		}
+		for _, topic := range log.Topics[1:] {
+			switch topic {
+			case recipient:
+				return DepositMinted
+			case fallbackAddress:
+				mintedFallback = true
+			}
+		}
+	}
+	if mintedFallback {
+		return DepositMintedToFallback
+	}
+	return DepositMinted
+}
@@ -31,6 +31,7 @@ var Modules = map[string]string{
 	"les":      LESJs,
 	"vflux":    VfluxJs,
 	"dev":      DevJs,
+	"astria":   AstriaJs,
 }
 
 const CliqueJs = `
@@ -888,3 +889,30 @@ web3._extend({
 	],
 });
 `
+
+const AstriaJs = `
+web3._extend({
+	property: 'astria',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'auctioneerAddresses',
+			call: 'astria_auctioneerAddresses',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'gasUsage',
+			call: 'astria_gasUsage',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'bridgeSupply',
+			call: 'astria_bridgeSupply',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+	],
+});
+`
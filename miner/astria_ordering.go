@@ -0,0 +1,69 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// sortAstriaSenderNonces returns the sequenced transactions with the transactions of each
+// sender sorted by nonce, so that a nonce sequenced before a lower one of the same sender
+// does not fail on a nonce gap. Every sender keeps the positions its transactions were
+// sequenced at, so the transactions of other senders are not moved. Transactions with the
+// same nonce keep their sequenced order. Deposits and transactions whose sender cannot be
+// recovered are left in place.
+func sortAstriaSenderNonces(txs types.Transactions, signer types.Signer) types.Transactions {
+	var (
+		positions = make(map[common.Address][]int)
+		senders   []common.Address
+	)
+	for i, tx := range txs {
+		if tx.Type() == types.DepositTxType {
+			continue
+		}
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		if _, ok := positions[from]; !ok {
+			senders = append(senders, from)
+		}
+		positions[from] = append(positions[from], i)
+	}
+	sorted := make(types.Transactions, len(txs))
+	copy(sorted, txs)
+	for _, from := range senders {
+		slots := positions[from]
+		if len(slots) < 2 {
+			continue
+		}
+		senderTxs := make(types.Transactions, len(slots))
+		for j, slot := range slots {
+			senderTxs[j] = txs[slot]
+		}
+		sort.SliceStable(senderTxs, func(a, b int) bool {
+			return senderTxs[a].Nonce() < senderTxs[b].Nonce()
+		})
+		for j, slot := range slots {
+			sorted[slot] = senderTxs[j]
+		}
+	}
+	return sorted
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSortAstriaSenderNonces(t *testing.T) {
+	var (
+		signer  = types.LatestSigner(params.TestChainConfig)
+		keyA, _ = crypto.GenerateKey()
+		keyB, _ = crypto.GenerateKey()
+		deposit = types.NewTx(&types.DepositTx{Value: big.NewInt(1), To: &common.Address{}})
+	)
+	tx := func(key *ecdsa.PrivateKey, nonce uint64, value int64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &common.Address{},
+			Value:    big.NewInt(value),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(params.InitialBaseFee),
+		})
+	}
+	var (
+		a2  = tx(keyA, 2, 0)
+		b1  = tx(keyB, 1, 0)
+		a0  = tx(keyA, 0, 0)
+		a1  = tx(keyA, 1, 0)
+		a1b = tx(keyA, 1, 1) // same nonce as a1, sequenced later
+		b0  = tx(keyB, 0, 0)
+	)
+	txs := types.Transactions{a2, b1, deposit, a0, a1b, b0, a1}
+	want := types.Transactions{a0, b0, deposit, a1b, a1, b1, a2}
+
+	sorted := sortAstriaSenderNonces(txs, signer)
+	if len(sorted) != len(want) {
+		t.Fatalf("unexpected number of transactions: have %d, want %d", len(sorted), len(want))
+	}
+	for i := range want {
+		if sorted[i].Hash() != want[i].Hash() {
+			t.Errorf("position %d: have %x, want %x", i, sorted[i].Hash(), want[i].Hash())
+		}
+	}
+	// the sequenced order is left untouched
+	if txs[0] != a2 || txs[6] != a1 {
+		t.Errorf("sequenced transactions were reordered in place")
+	}
+}
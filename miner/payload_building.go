@@ -35,16 +35,14 @@ import (
 // Check engine-api specification for more details.
 // https://github.com/ethereum/execution-apis/blob/main/src/engine/cancun.md#payloadattributesv3
 type BuildPayloadArgs struct {
-	Parent               common.Hash           // The parent block to build payload on top
-	Timestamp            uint64                // The provided timestamp of generated payload
-	FeeRecipient         common.Address        // The provided recipient address for collecting transaction fee
-	Random               common.Hash           // The provided randomness value
-	Withdrawals          types.Withdrawals     // The provided withdrawals
-	BeaconRoot           *common.Hash          // The provided beaconRoot (Cancun)
-	Version              engine.PayloadVersion // Versioning byte for payload id calculation.
-	OverrideTransactions types.Transactions    // Transactions to use during payload building. Currently this is mainly used
-	// during optimistic block execution.
-	IsOptimisticExecution bool // Whether the payload is for optimistic execution
+	Parent       common.Hash           // The parent block to build payload on top
+	Timestamp    uint64                // The provided timestamp of generated payload
+	FeeRecipient common.Address        // The provided recipient address for collecting transaction fee
+	Random       common.Hash           // The provided randomness value
+	Withdrawals  types.Withdrawals     // The provided withdrawals
+	BeaconRoot   *common.Hash          // The provided beaconRoot (Cancun)
+	Version      engine.PayloadVersion // Versioning byte for payload id calculation.
+	Transactions types.Transactions    // The ordered transactions to include in the payload
 }
 
 // Id computes an 8-byte identifier by hashing the components of the payload arguments.
@@ -75,6 +73,7 @@ type Payload struct {
 	full     *types.Block
 	sidecars []*types.BlobTxSidecar
 	fullFees *big.Int
+	excluded types.Transactions
 	stop     chan struct{}
 	lock     sync.Mutex
 	cond     *sync.Cond
@@ -109,6 +108,7 @@ func (payload *Payload) update(r *newPayloadResult, elapsed time.Duration) {
 		payload.full = r.block
 		payload.fullFees = r.fees
 		payload.sidecars = r.sidecars
+		payload.excluded = r.excluded
 
 		feesInEther := new(big.Float).Quo(new(big.Float).SetInt(r.fees), big.NewFloat(params.Ether))
 		log.Info("Updated payload",
@@ -143,6 +143,15 @@ func (payload *Payload) Resolve() *engine.ExecutionPayloadEnvelope {
 	return engine.BlockToExecutableData(payload.empty, big.NewInt(0), nil)
 }
 
+// ExcludedTransactions returns the transactions of the payload arguments which were not
+// included in the full block, so that the caller can evict them from the mempool.
+func (payload *Payload) ExcludedTransactions() types.Transactions {
+	payload.lock.Lock()
+	defer payload.lock.Unlock()
+
+	return payload.excluded
+}
+
 // ResolveEmpty is basically identical to Resolve, but it expects empty block only.
 // It's only used in tests.
 func (payload *Payload) ResolveEmpty() *engine.ExecutionPayloadEnvelope {
@@ -184,16 +193,15 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs) (*Payload, error) {
 	// enough to run. The empty payload can at least make sure there is something
 	// to deliver for not missing slot.
 	fullParams := &generateParams{
-		timestamp:             args.Timestamp,
-		forceTime:             true,
-		parentHash:            args.Parent,
-		coinbase:              args.FeeRecipient,
-		random:                args.Random,
-		withdrawals:           args.Withdrawals,
-		beaconRoot:            args.BeaconRoot,
-		noTxs:                 false,
-		overrideTransactions:  args.OverrideTransactions,
-		isOptimisticExecution: args.IsOptimisticExecution,
+		timestamp:    args.Timestamp,
+		forceTime:    true,
+		parentHash:   args.Parent,
+		coinbase:     args.FeeRecipient,
+		random:       args.Random,
+		withdrawals:  args.Withdrawals,
+		beaconRoot:   args.BeaconRoot,
+		noTxs:        false,
+		transactions: args.Transactions,
 	}
 
 	start := time.Now()
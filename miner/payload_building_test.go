@@ -52,6 +52,10 @@ var (
 	testUserKey, _  = crypto.GenerateKey()
 	testUserAddress = crypto.PubkeyToAddress(testUserKey.PublicKey)
 
+	// Test contract storing the first byte of the calldata, which a deposit calls
+	testContractAddress = common.HexToAddress("0xc0ffee")
+	testContractCode    = common.FromHex("60003560f81c600055") // PUSH1 0 CALLDATALOAD PUSH1 248 SHR PUSH1 0 SSTORE
+
 	// Test transactions
 	pendingTxs []*types.Transaction
 	newTxs     []*types.Transaction
@@ -107,7 +111,10 @@ type testWorkerBackend struct {
 func newTestWorkerBackend(t *testing.T, chainConfig *params.ChainConfig, engine consensus.Engine, db ethdb.Database, n int) *testWorkerBackend {
 	var gspec = &core.Genesis{
 		Config: chainConfig,
-		Alloc:  core.GenesisAlloc{testBankAddress: {Balance: testBankFunds}},
+		Alloc: core.GenesisAlloc{
+			testBankAddress:     {Balance: testBankFunds},
+			testContractAddress: {Code: testContractCode},
+		},
 	}
 	switch e := engine.(type) {
 	case *clique.Clique:
@@ -196,25 +203,12 @@ func TestBuildPayloadNotEnoughGas(t *testing.T) {
 		signedTxs = append(signedTxs, signedTx)
 	}
 
-	// set the astria ordered txsToBuildPayload
-	b.TxPool().SetAstriaOrdered(signedTxs)
-	astriaTxs := b.TxPool().AstriaOrdered()
-
-	if astriaTxs.Len() != len(txsToAdd) {
-		t.Fatalf("Unexpected number of astria ordered transactions: %d", astriaTxs.Len())
-	}
-
-	txs := types.TxDifference(*astriaTxs, signedTxs)
-	if txs.Len() != 0 {
-		t.Fatalf("Unexpected transactions in astria ordered transactions: %v", txs)
-	}
-
 	args := &BuildPayloadArgs{
-		Parent:                b.chain.CurrentBlock().Hash(),
-		Timestamp:             timestamp,
-		Random:                common.Hash{},
-		FeeRecipient:          recipient,
-		IsOptimisticExecution: false,
+		Parent:       b.chain.CurrentBlock().Hash(),
+		Timestamp:    timestamp,
+		Random:       common.Hash{},
+		FeeRecipient: recipient,
+		Transactions: signedTxs,
 	}
 
 	payload, err := w.buildPayload(args)
@@ -222,7 +216,7 @@ func TestBuildPayloadNotEnoughGas(t *testing.T) {
 		t.Fatalf("Failed to build payload %v", err)
 	}
 	full := payload.ResolveFull()
-	astriaExcludedFromBlock := b.TxPool().AstriaExcludedFromBlock()
+	astriaExcludedFromBlock := payload.ExcludedTransactions()
 
 	// ensure that the transactions not included in the block are included in astriaExcludedFromBlock
 	excludedTxsCount := len(txsToAdd) - len(full.ExecutionPayload.Transactions)
@@ -238,7 +232,7 @@ func TestBuildPayloadNotEnoughGas(t *testing.T) {
 			if err != nil {
 				t.Fatalf("Failed to unmarshal binary transaction %v", err)
 			}
-			if contains(*astriaExcludedFromBlock, tx) {
+			if contains(astriaExcludedFromBlock, tx) {
 				t.Fatalf("Transaction %v should not be in the astria excluded from block list", tx)
 			}
 		}
@@ -281,19 +275,6 @@ func TestBuildPayloadTimeout(t *testing.T) {
 		signedTxs = append(signedTxs, signedTx)
 	}
 
-	// set the astria ordered txsToBuildPayload
-	b.TxPool().SetAstriaOrdered(signedTxs)
-	astriaTxs := b.TxPool().AstriaOrdered()
-
-	if astriaTxs.Len() != len(txsToAdd) {
-		t.Fatalf("Unexpected number of astria ordered transactions: %d", astriaTxs.Len())
-	}
-
-	txs := types.TxDifference(*astriaTxs, signedTxs)
-	if txs.Len() != 0 {
-		t.Fatalf("Unexpected transactions in astria ordered transactions: %v", txs)
-	}
-
 	// a very small recommit to reliably timeout the payload building
 	w.config.Recommit = time.Nanosecond
 	args := &BuildPayloadArgs{
@@ -301,6 +282,7 @@ func TestBuildPayloadTimeout(t *testing.T) {
 		Timestamp:    timestamp,
 		Random:       common.Hash{},
 		FeeRecipient: recipient,
+		Transactions: signedTxs,
 	}
 
 	payload, err := w.buildPayload(args)
@@ -308,7 +290,7 @@ func TestBuildPayloadTimeout(t *testing.T) {
 		t.Fatalf("Failed to build payload %v", err)
 	}
 	full := payload.ResolveFull()
-	astriaExcludedFromBlock := b.TxPool().AstriaExcludedFromBlock()
+	astriaExcludedFromBlock := payload.ExcludedTransactions()
 
 	// ensure that the transactions not included in the block are included in astriaExcludedFromBlock
 	excludedTxsCount := len(txsToAdd) - len(full.ExecutionPayload.Transactions)
@@ -324,7 +306,7 @@ func TestBuildPayloadTimeout(t *testing.T) {
 			if err != nil {
 				t.Fatalf("Failed to unmarshal binary transaction %v", err)
 			}
-			if contains(*astriaExcludedFromBlock, tx) {
+			if contains(astriaExcludedFromBlock, tx) {
 				t.Fatalf("Transaction %v should not be in the astria excluded from block list", tx)
 			}
 		}
@@ -447,24 +429,12 @@ func TestBuildPayload(t *testing.T) {
 				signedInvalidTxs = append(signedInvalidTxs, signedTx)
 			}
 
-			// set the astria ordered txsToBuildPayload
-			b.TxPool().SetAstriaOrdered(signedTxs)
-			astriaTxs := b.TxPool().AstriaOrdered()
-
-			if astriaTxs.Len() != len(tt.txsToBuildPayload) {
-				t.Fatalf("Unexpected number of astria ordered transactions: %d", astriaTxs.Len())
-			}
-
-			txs := types.TxDifference(*astriaTxs, signedTxs)
-			if txs.Len() != 0 {
-				t.Fatalf("Unexpected transactions in astria ordered transactions: %v", txs)
-			}
-
 			args := &BuildPayloadArgs{
 				Parent:       b.chain.CurrentBlock().Hash(),
 				Timestamp:    timestamp,
 				Random:       common.Hash{},
 				FeeRecipient: recipient,
+				Transactions: signedTxs,
 			}
 
 			payload, err := w.buildPayload(args)
@@ -484,8 +454,8 @@ func TestBuildPayload(t *testing.T) {
 
 			// Ensure invalid transactions are stored
 			if len(tt.txsExcludedFromBlock) > 0 {
-				invalidTxs := b.TxPool().AstriaExcludedFromBlock()
-				txDifference := types.TxDifference(*invalidTxs, signedInvalidTxs)
+				invalidTxs := payload.ExcludedTransactions()
+				txDifference := types.TxDifference(invalidTxs, signedInvalidTxs)
 				if txDifference.Len() != 0 {
 					t.Fatalf("Unexpected transactions in transactions excluded from block list: %v", txDifference)
 				}
@@ -494,6 +464,271 @@ func TestBuildPayload(t *testing.T) {
 	}
 }
 
+func TestBuildPayloadScheduledGasLimit(t *testing.T) {
+	const scheduledGasLimit = 2 * params.GenesisGasLimit
+	chainConfig := new(params.ChainConfig)
+	*chainConfig = *params.TestChainConfig
+	chainConfig.AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+		1: {
+			ElasticityMultiplier:     params.DefaultElasticityMultiplier,
+			BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator,
+			GasLimit:                 scheduledGasLimit,
+		},
+	})
+	w, b := newTestWorker(t, chainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	// the gas ceiling of the miner would only allow the gas limit to drift from the genesis one
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:       b.chain.CurrentBlock().Hash(),
+		Timestamp:    uint64(time.Now().Unix()),
+		FeeRecipient: common.HexToAddress("0xdeadbeef"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	if gasLimit := payload.ResolveFull().ExecutionPayload.GasLimit; gasLimit != scheduledGasLimit {
+		t.Fatalf("Unexpected gas limit: have %d, want %d", gasLimit, scheduledGasLimit)
+	}
+
+	// a transaction above the gas limit of the parent fits the scheduled gas limit
+	signer := types.LatestSigner(chainConfig)
+	tx := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+		To:       &testUserAddress,
+		Gas:      b.chain.CurrentBlock().GasLimit + 1,
+		GasPrice: big.NewInt(10 * params.InitialBaseFee),
+	})
+	payload, err = w.buildPayload(&BuildPayloadArgs{
+		Parent:       b.chain.CurrentBlock().Hash(),
+		Timestamp:    uint64(time.Now().Unix()),
+		FeeRecipient: common.HexToAddress("0xdeadbeef"),
+		Transactions: types.Transactions{tx},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	if included := len(payload.ResolveFull().ExecutionPayload.Transactions); included != 1 {
+		t.Fatalf("Unexpected number of transactions in block: have %d, want 1", included)
+	}
+}
+
+func TestBuildPayloadDepositGasLimit(t *testing.T) {
+	const (
+		userGasLimit    = 3 * params.TxGas
+		depositGasLimit = params.TxGas
+	)
+	chainConfig := new(params.ChainConfig)
+	*chainConfig = *params.TestChainConfig
+	chainConfig.AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+		1: {
+			ElasticityMultiplier:     params.DefaultElasticityMultiplier,
+			BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator,
+			GasLimit:                 userGasLimit,
+			DepositGasLimit:          depositGasLimit,
+		},
+	})
+	w, b := newTestWorker(t, chainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	// one more user transaction than the user gas limit allows, which may not use the
+	// gas reserved for deposits
+	signer := types.LatestSigner(chainConfig)
+	txs := types.Transactions{}
+	for nonce := uint64(0); nonce < 4; nonce++ {
+		txs = append(txs, types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &testUserAddress,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(10 * params.InitialBaseFee),
+		}))
+	}
+
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:       b.chain.CurrentBlock().Hash(),
+		Timestamp:    uint64(time.Now().Unix()),
+		FeeRecipient: common.HexToAddress("0xdeadbeef"),
+		Transactions: txs,
+	})
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	full := payload.ResolveFull().ExecutionPayload
+	if full.GasLimit != userGasLimit+depositGasLimit {
+		t.Fatalf("Unexpected gas limit: have %d, want %d", full.GasLimit, userGasLimit+depositGasLimit)
+	}
+	if len(full.Transactions) != 3 {
+		t.Fatalf("Unexpected number of transactions in block: have %d, want 3", len(full.Transactions))
+	}
+	if full.GasUsed != userGasLimit {
+		t.Fatalf("Unexpected gas used: have %d, want %d", full.GasUsed, userGasLimit)
+	}
+	if excluded := payload.ExcludedTransactions(); excluded.Len() != 1 || excluded[0].Hash() != txs[3].Hash() {
+		t.Fatalf("Unexpected transactions excluded from block: %v", excluded)
+	}
+}
+
+// Tests that the gas used by deposits beyond their reservation is taken from the gas
+// available to user transactions.
+func TestBuildPayloadDepositGasUsed(t *testing.T) {
+	const (
+		userGasLimit    = 3 * params.TxGas
+		depositGasLimit = params.TxGas
+	)
+	chainConfig := new(params.ChainConfig)
+	*chainConfig = *params.TestChainConfig
+	chainConfig.AstriaEIP1559Params = params.NewAstriaEIP1559Params(map[uint64]params.AstriaEIP1559Param{
+		1: {
+			ElasticityMultiplier:     params.DefaultElasticityMultiplier,
+			BaseFeeChangeDenominator: params.DefaultBaseFeeChangeDenominator,
+			GasLimit:                 userGasLimit,
+			DepositGasLimit:          depositGasLimit,
+		},
+	})
+	w, b := newTestWorker(t, chainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	// the deposit writes a fresh storage slot, using more than the gas reserved for
+	// deposits, so only two of the user transactions fit into the block
+	deposit := types.NewTx(&types.DepositTx{
+		From:  testUserAddress,
+		Value: new(big.Int),
+		Gas:   50_000,
+		To:    &testContractAddress,
+		Data:  []byte{0x01},
+	})
+	txs := types.Transactions{deposit}
+	signer := types.LatestSigner(chainConfig)
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		txs = append(txs, types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &testUserAddress,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(10 * params.InitialBaseFee),
+		}))
+	}
+
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:       b.chain.CurrentBlock().Hash(),
+		Timestamp:    uint64(time.Now().Unix()),
+		FeeRecipient: common.HexToAddress("0xdeadbeef"),
+		Transactions: txs,
+	})
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	full := payload.ResolveFull().ExecutionPayload
+	if len(full.Transactions) != 3 {
+		t.Fatalf("Unexpected number of transactions in block: have %d, want 3", len(full.Transactions))
+	}
+	if full.GasUsed <= depositGasLimit+2*params.TxGas || full.GasUsed > full.GasLimit {
+		t.Fatalf("Unexpected gas used: have %d, want above %d and at most %d", full.GasUsed, depositGasLimit+2*params.TxGas, full.GasLimit)
+	}
+	if excluded := payload.ExcludedTransactions(); excluded.Len() != 1 || excluded[0].Hash() != txs[3].Hash() {
+		t.Fatalf("Unexpected transactions excluded from block: %v", excluded)
+	}
+	// the block built is accepted by the chain
+	if _, err := b.chain.InsertChain(types.Blocks{payload.full}); err != nil {
+		t.Fatalf("Failed to insert block: %v", err)
+	}
+}
+
+func TestBuildPayloadNonceReordering(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		chainConfig := new(params.ChainConfig)
+		*chainConfig = *params.TestChainConfig
+		if enabled {
+			chainConfig.AstriaNonceReorderingHeight = big.NewInt(0)
+		}
+		w, b := newTestWorker(t, chainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+		// the second nonce of the sender is sequenced before the first one
+		signer := types.LatestSigner(chainConfig)
+		txs := types.Transactions{}
+		for _, nonce := range []uint64{1, 0} {
+			txs = append(txs, types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+				Nonce:    nonce,
+				To:       &testUserAddress,
+				Value:    big.NewInt(1000),
+				Gas:      params.TxGas,
+				GasPrice: big.NewInt(10 * params.InitialBaseFee),
+			}))
+		}
+
+		payload, err := w.buildPayload(&BuildPayloadArgs{
+			Parent:       b.chain.CurrentBlock().Hash(),
+			Timestamp:    uint64(time.Now().Unix()),
+			FeeRecipient: common.HexToAddress("0xdeadbeef"),
+			Transactions: txs,
+		})
+		if err != nil {
+			t.Fatalf("Failed to build payload %v", err)
+		}
+		want := 1
+		if enabled {
+			want = 2
+		}
+		if included := len(payload.ResolveFull().ExecutionPayload.Transactions); included != want {
+			t.Errorf("reordering enabled %v: unexpected number of transactions in block: have %d, want %d", enabled, included, want)
+		}
+	}
+}
+
+// Tests that sequenced transactions which are invalid according to the consensus rules
+// are excluded from the block and reported as excluded.
+func TestBuildPayloadValidatesTransactions(t *testing.T) {
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	signer := types.LatestSigner(params.TestChainConfig)
+	gasPrice := big.NewInt(10 * params.InitialBaseFee)
+	valid := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{Nonce: 0, To: &testUserAddress, Gas: params.TxGas, GasPrice: gasPrice})
+	intrinsicGasTooLow := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{Nonce: 1, To: &testUserAddress, Gas: params.TxGas - 1, GasPrice: gasPrice})
+	aboveBlockGasLimit := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{Nonce: 1, To: &testUserAddress, Gas: b.chain.CurrentBlock().GasLimit + 1, GasPrice: gasPrice})
+
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:       b.chain.CurrentBlock().Hash(),
+		Timestamp:    uint64(time.Now().Unix()),
+		FeeRecipient: common.HexToAddress("0xdeadbeef"),
+		Transactions: types.Transactions{intrinsicGasTooLow, valid, aboveBlockGasLimit},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	if included := len(payload.ResolveFull().ExecutionPayload.Transactions); included != 1 {
+		t.Fatalf("Unexpected number of transactions in block: have %d, want 1", included)
+	}
+	excluded := payload.ExcludedTransactions()
+	if excluded.Len() != 2 || excluded[0].Hash() != intrinsicGasTooLow.Hash() || excluded[1].Hash() != aboveBlockGasLimit.Hash() {
+		t.Fatalf("Unexpected transactions excluded from block: %v", excluded)
+	}
+}
+
+func TestAstriaGasBudget(t *testing.T) {
+	var (
+		deposit = types.NewTx(&types.DepositTx{Value: new(big.Int)})
+		user    = types.NewTransaction(0, testUserAddress, big.NewInt(0), params.TxGas, big.NewInt(1), nil)
+		budget  = &astriaGasBudget{depositGasLimit: 100, userGasLimit: 1000}
+	)
+	if available := budget.available(deposit, 1100); available != 1100 {
+		t.Errorf("deposit: have %d available, want 1100", available)
+	}
+	if available := budget.available(user, 1100); available != 1000 {
+		t.Errorf("user: have %d available, want 1000", available)
+	}
+	// deposits within their reservation do not reduce the user gas
+	budget.add(deposit, 100)
+	if available := budget.available(user, 1000); available != 1000 {
+		t.Errorf("user after reserved deposits: have %d available, want 1000", available)
+	}
+	// deposits beyond their reservation do
+	budget.add(deposit, 200)
+	if available := budget.available(user, 800); available != 800 {
+		t.Errorf("user after excess deposits: have %d available, want 800", available)
+	}
+	budget.add(user, 800)
+	if available := budget.available(user, 0); available != 0 {
+		t.Errorf("user after exhausted budget: have %d available, want 0", available)
+	}
+}
+
 func TestPayloadId(t *testing.T) {
 	t.Parallel()
 	ids := make(map[string]int)
@@ -27,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
@@ -39,6 +40,10 @@ var (
 	errBlockInterruptedByTimeout  = errors.New("timeout while building block")
 )
 
+// astriaTxMaxSize is the maximum size a single sequenced transaction can have, matching
+// the maximum size of a transaction accepted by the legacy pool.
+const astriaTxMaxSize = 4 * 32 * 1024 // 128KB
+
 // environment is the worker's current environment and holds all
 // information of the sealing block generation.
 type environment struct {
@@ -70,20 +75,20 @@ type newPayloadResult struct {
 	sidecars []*types.BlobTxSidecar // collected blobs of blob transactions
 	stateDB  *state.StateDB         // StateDB after executing the transactions
 	receipts []*types.Receipt       // Receipts collected during construction
+	excluded types.Transactions     // Ordered transactions not included in the block
 }
 
 // generateParams wraps various of settings for generating sealing task.
 type generateParams struct {
-	timestamp             uint64             // The timstamp for sealing task
-	forceTime             bool               // Flag whether the given timestamp is immutable or not
-	parentHash            common.Hash        // Parent block hash, empty means the latest chain head
-	coinbase              common.Address     // The fee recipient address for including transaction
-	random                common.Hash        // The randomness generated by beacon chain, empty before the merge
-	withdrawals           types.Withdrawals  // List of withdrawals to include in block.
-	beaconRoot            *common.Hash       // The beacon root (cancun field).
-	noTxs                 bool               // Flag whether an empty block without any transaction is expected
-	overrideTransactions  types.Transactions // Transactions to use during payload building
-	isOptimisticExecution bool               // Flag whether the payload is for optimistic execution
+	timestamp    uint64             // The timstamp for sealing task
+	forceTime    bool               // Flag whether the given timestamp is immutable or not
+	parentHash   common.Hash        // Parent block hash, empty means the latest chain head
+	coinbase     common.Address     // The fee recipient address for including transaction
+	random       common.Hash        // The randomness generated by beacon chain, empty before the merge
+	withdrawals  types.Withdrawals  // List of withdrawals to include in block.
+	beaconRoot   *common.Hash       // The beacon root (cancun field).
+	noTxs        bool               // Flag whether an empty block without any transaction is expected
+	transactions types.Transactions // The ordered transactions to include in the block
 }
 
 // prepareWork constructs the sealing task according to the given parameters,
@@ -134,6 +139,10 @@ func (miner *Miner) prepareWork(genParams *generateParams) (*environment, error)
 			parentGasLimit := parent.GasLimit * miner.chainConfig.ElasticityMultiplier(parent.Number.Uint64())
 			header.GasLimit = core.CalcGasLimit(parentGasLimit, miner.config.GasCeil)
 		}
+		// The scheduled gas limit takes precedence over the gas ceiling
+		if gasLimit, ok := miner.chainConfig.AstriaGasLimit(header.Number.Uint64()); ok {
+			header.GasLimit = gasLimit
+		}
 	}
 	// Run the consensus preparation with the default or customized consensus engine.
 	// Note that the `header.Time` may be changed.
@@ -240,38 +249,37 @@ func (miner *Miner) applyTransaction(env *environment, tx *types.Transaction) (*
 	return receipt, err
 }
 
-// This is a copy of commitTransactions, but updated to take a list of txs instead of using heap
-func (miner *Miner) commitAstriaTransactions(env *environment, txs *types.Transactions, isOptimisticExecution bool, interrupt *atomic.Int32) error {
+// This is a copy of commitTransactions, but updated to take a list of txs instead of using heap.
+// It returns the transactions which were not included in the block.
+func (miner *Miner) commitAstriaTransactions(env *environment, txs types.Transactions, interrupt *atomic.Int32) (types.Transactions, error) {
 	gasLimit := env.header.GasLimit
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
 	}
+	// Sort the transactions of each sender by nonce, so that none fails on a nonce gap
+	if miner.chainConfig.IsAstriaNonceReordering(env.header.Number) {
+		txs = sortAstriaSenderNonces(txs, env.signer)
+	}
+	// If gas is reserved for deposits, user transactions may not use it
+	var budget *astriaGasBudget
+	if depositGasLimit := miner.chainConfig.AstriaDepositGasLimit(env.header.Number.Uint64()); depositGasLimit > 0 {
+		budget = &astriaGasBudget{depositGasLimit: depositGasLimit, userGasLimit: gasLimit - depositGasLimit}
+	}
 
-	for i, tx := range *txs {
+	var excluded types.Transactions
+	for i, tx := range txs {
 		// Check interruption signal and abort building if it's fired.
 		if interrupt != nil {
 			if signal := interrupt.Load(); signal != commitInterruptNone {
-				// we do not need to remove failing txs from the mempool during optimistic execution as they would
-				// anyways be removed when the block is built by the main execution path. Regardless, if the node is being
-				// run as an auctioneer node, the mempool is cleared after every optimistic execution round.
-				if !isOptimisticExecution {
-					// remove the subsequent txs from the mempool if block building has been interrupted
-					for _, txToRemove := range (*txs)[i:] {
-						miner.txpool.AddToAstriaExcludedFromBlock(txToRemove)
-					}
-				}
-				return signalToErr(signal)
+				// the subsequent txs are not included if block building has been interrupted
+				excluded = append(excluded, txs[i:]...)
+				return excluded, signalToErr(signal)
 			}
 		}
 		// If we don't have enough gas for any further transactions then we're done.
 		if env.gasPool.Gas() < params.TxGas {
 			log.Trace("Not enough gas for further transactions", "have", env.gasPool, "want", params.TxGas)
-			// remove txs from the mempool if they are too big for this block
-			if !isOptimisticExecution {
-				for _, txToRemove := range (*txs)[i:] {
-					miner.txpool.AddToAstriaExcludedFromBlock(txToRemove)
-				}
-			}
+			excluded = append(excluded, txs[i:]...)
 			break
 		}
 
@@ -283,15 +291,18 @@ func (miner *Miner) commitAstriaTransactions(env *environment, txs *types.Transa
 		// phase, start ignoring the sender until we do.
 		if tx.Protected() && !miner.chainConfig.IsEIP155(env.header.Number) {
 			log.Trace("Ignoring reply protected transaction", "hash", tx.Hash(), "eip155", miner.chainConfig.EIP155Block)
-			if !isOptimisticExecution {
-				miner.txpool.AddToAstriaExcludedFromBlock(tx)
-			}
+			excluded = append(excluded, tx)
 			continue
 		}
 		// Start executing the transaction
 		env.state.SetTxContext(tx.Hash(), env.tcount)
 
-		err := miner.commitTransaction(env, tx)
+		var err error
+		if budget != nil {
+			err = miner.commitBudgetedTransaction(env, tx, budget)
+		} else {
+			err = miner.commitTransaction(env, tx)
+		}
 		switch {
 		case errors.Is(err, core.ErrNonceTooLow):
 			// New head notification data race between the transaction pool and miner, shift
@@ -301,35 +312,110 @@ func (miner *Miner) commitAstriaTransactions(env *environment, txs *types.Transa
 			// nonce-too-high clause will prevent us from executing in vain).
 			log.Debug("Transaction failed, account skipped", "hash", tx.Hash(), "err", err)
 		}
-		if err != nil && !isOptimisticExecution {
-			log.Trace("Marking transaction as invalid", "hash", tx.Hash(), "err", err)
-			miner.txpool.AddToAstriaExcludedFromBlock(tx)
+		if err != nil {
+			log.Trace("Marking transaction as excluded", "hash", tx.Hash(), "err", err)
+			excluded = append(excluded, tx)
 		}
 	}
 
-	return nil
+	return excluded, nil
+}
+
+// astriaGasBudget splits the gas limit of a block between the gas reserved for deposit
+// transactions and the gas available to user transactions.
+type astriaGasBudget struct {
+	depositGasLimit uint64
+	userGasLimit    uint64
+	depositGasUsed  uint64
+	userGasUsed     uint64
+}
+
+// available returns the gas the transaction may use out of the gas remaining in the
+// block. Deposits may use any remaining gas, while user transactions may only use the
+// user gas limit, minus the gas used by deposits beyond their reservation.
+func (b *astriaGasBudget) available(tx *types.Transaction, remaining uint64) uint64 {
+	if tx.Type() == types.DepositTxType {
+		return remaining
+	}
+	used := b.userGasUsed
+	if b.depositGasUsed > b.depositGasLimit {
+		used += b.depositGasUsed - b.depositGasLimit
+	}
+	if used >= b.userGasLimit {
+		return 0
+	}
+	return min(remaining, b.userGasLimit-used)
+}
+
+func (b *astriaGasBudget) add(tx *types.Transaction, gasUsed uint64) {
+	if tx.Type() == types.DepositTxType {
+		b.depositGasUsed += gasUsed
+	} else {
+		b.userGasUsed += gasUsed
+	}
+}
+
+// commitBudgetedTransaction commits the transaction, limiting the gas it may use to its
+// share of the block gas budget.
+func (miner *Miner) commitBudgetedTransaction(env *environment, tx *types.Transaction, budget *astriaGasBudget) error {
+	remaining := env.gasPool.Gas()
+	env.gasPool.SetGas(budget.available(tx, remaining))
+	err := miner.commitTransaction(env, tx)
+	var used uint64
+	if err == nil {
+		// Deposits do not buy their gas from the pool, so it is taken from the receipt
+		used = env.receipts[len(env.receipts)-1].GasUsed
+	}
+	budget.add(tx, used)
+	env.gasPool.SetGas(remaining - min(used, remaining))
+	return err
 }
 
-func (miner *Miner) fillAstriaTransactions(interrupt *atomic.Int32, env *environment, overrideTransactions types.Transactions, isOptimisticExecution bool) error {
-	// TODO - the below setup should be refactored. We use the `AstriaOrdered` pool to store the transactions during regular execution
-	// and we use the `overrideTransactions` to store the transactions during optimistic execution. This is a bit confusing and should be
-	// refactored to use a single way of tx passing. Ideally, we would want to avoid using the `AstriaOrdered` pool but we would
-	// have to figure out how to handle transactions which do not fit into the block. We currently store these txs in the `AstriaExcludedFromBlock`
-	// array and remove these txs from the mempool when the block is built.
-
-	// Use pre ordered array of txs
-	astriaTxs := miner.txpool.AstriaOrdered()
-	// use the override transactions instead of astria txs
-	if isOptimisticExecution {
-		astriaTxs = &overrideTransactions
-	}
-	if len(*astriaTxs) > 0 {
-		if err := miner.commitAstriaTransactions(env, astriaTxs, isOptimisticExecution, interrupt); err != nil {
-			return err
+// fillAstriaTransactions validates the ordered transactions and commits the valid ones to
+// the block in order. It returns the transactions which were not included in the block,
+// so that the caller can decide whether to evict them from the mempool.
+func (miner *Miner) fillAstriaTransactions(interrupt *atomic.Int32, env *environment, txs types.Transactions) (types.Transactions, error) {
+	if len(txs) == 0 {
+		return nil, nil
+	}
+	parent := miner.chain.GetHeader(env.header.ParentHash, env.header.Number.Uint64()-1)
+	if parent == nil {
+		return txs, errors.New("missing parent")
+	}
+	// transactions are validated against the gas limit of the block being built, which may
+	// differ from the one of its parent if a new gas limit is scheduled
+	head := types.CopyHeader(parent)
+	head.GasLimit = env.header.GasLimit
+	var valid, excluded types.Transactions
+	for idx, tx := range txs {
+		if err := miner.validateAstriaTx(tx, head, env.signer); err != nil {
+			log.Warn("astria tx failed validation", "index", idx, "hash", tx.Hash(), "error", err)
+			excluded = append(excluded, tx)
+			continue
 		}
+		valid = append(valid, tx)
 	}
+	notIncluded, err := miner.commitAstriaTransactions(env, valid, interrupt)
+	return append(excluded, notIncluded...), err
+}
 
-	return nil
+// validateAstriaTx checks whether a sequenced transaction is valid according to the
+// consensus rules. Sequenced transactions are included in the block in the order set by
+// the sequencer, so this must not depend on any local setting of the node such as the
+// minimum gas tip of the mempool, otherwise nodes could derive different blocks from the
+// same sequencer data.
+func (miner *Miner) validateAstriaTx(tx *types.Transaction, parent *types.Header, signer types.Signer) error {
+	opts := &txpool.ValidationOptions{
+		Config: miner.chainConfig,
+		Accept: 0 |
+			1<<types.LegacyTxType |
+			1<<types.AccessListTxType |
+			1<<types.DynamicFeeTxType |
+			1<<types.DepositTxType,
+		MaxSize: astriaTxMaxSize,
+		MinTip:  new(big.Int),
+	}
+	return txpool.ValidateTransaction(tx, parent, signer, opts)
 }
 
 // generateWork generates a sealing block based on the given parameters.
@@ -338,14 +424,16 @@ func (miner *Miner) generateWork(params *generateParams) *newPayloadResult {
 	if err != nil {
 		return &newPayloadResult{err: err}
 	}
+	var excluded types.Transactions
 	if !params.noTxs {
 		interrupt := new(atomic.Int32)
 
-		err := miner.fillAstriaTransactions(interrupt, work, params.overrideTransactions, params.isOptimisticExecution)
+		excluded, err = miner.fillAstriaTransactions(interrupt, work, params.transactions)
 		if errors.Is(err, errBlockInterruptedByTimeout) {
 			log.Error("Block building is interrupted", "allowance", common.PrettyDuration(miner.config.Recommit))
 		}
 	}
+	core.ApplyAstriaFeeSplit(miner.chainConfig, work.header, work.state, work.txs, work.receipts)
 	body := types.Body{Transactions: work.txs, Withdrawals: params.withdrawals}
 	block, err := miner.engine.FinalizeAndAssemble(miner.chain, work.header, work.state, &body, work.receipts)
 	if err != nil {
@@ -357,6 +445,7 @@ func (miner *Miner) generateWork(params *generateParams) *newPayloadResult {
 		sidecars: work.sidecars,
 		stateDB:  work.state,
 		receipts: work.receipts,
+		excluded: excluded,
 	}
 }
 
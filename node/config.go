@@ -195,6 +195,9 @@ type Config struct {
 	GRPCHost string `toml:",omitempty"`
 	// GRPCPort is the TCP port number on which to start the gRPC server.
 	GRPCPort int `toml:",omitempty"`
+	// GRPCVerifySequencerProofs makes the gRPC execution service verify the rollup data
+	// of executed blocks against the sequencer block header sent along with them.
+	GRPCVerifySequencerProofs bool `toml:",omitempty"`
 
 	// Logger is a custom logger to use with the p2p.Server.
 	Logger log.Logger `toml:",omitempty"`
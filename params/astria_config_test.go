@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcutil/bech32"
@@ -214,6 +215,240 @@ func TestAstriaBridgeConfigValidation(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			description: "invalid failed mint recipient",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:  bridgeAddressBech32,
+				StartHeight:    2,
+				AssetDenom:     "nria",
+				AssetPrecision: 18,
+				Erc20Asset: &AstriaErc20AssetConfig{
+					ContractAddress:     erc20Asset,
+					ContractPrecision:   18,
+					FailedMintRecipient: &common.Address{},
+				},
+			},
+			wantErr: fmt.Errorf("failed mint recipient must not be the zero address"),
+		},
+		{
+			description: "invalid rate limit, no start height",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:  bridgeAddressBech32,
+				StartHeight:    2,
+				AssetDenom:     "nria",
+				AssetPrecision: 18,
+				RateLimit: &AstriaDepositRateLimit{
+					MaxBlockAmount: big.NewInt(100),
+				},
+			},
+			wantErr: fmt.Errorf("invalid rate limit: %w", fmt.Errorf("start height must be greater than 0")),
+		},
+		{
+			description: "invalid rate limit, window amount without window blocks",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:  bridgeAddressBech32,
+				StartHeight:    2,
+				AssetDenom:     "nria",
+				AssetPrecision: 18,
+				RateLimit: &AstriaDepositRateLimit{
+					StartHeight:     2,
+					MaxWindowAmount: big.NewInt(100),
+				},
+			},
+			wantErr: fmt.Errorf("invalid rate limit: %w", fmt.Errorf("window blocks must be set when max window amount is set")),
+		},
+		{
+			description: "invalid rate limit, zero block amount",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:  bridgeAddressBech32,
+				StartHeight:    2,
+				AssetDenom:     "nria",
+				AssetPrecision: 18,
+				RateLimit: &AstriaDepositRateLimit{
+					StartHeight:    2,
+					MaxBlockAmount: big.NewInt(0),
+				},
+			},
+			wantErr: fmt.Errorf("invalid rate limit: %w", fmt.Errorf("max block amount must be greater than 0")),
+		},
+		{
+			description: "invalid rate limit, unknown policy",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:  bridgeAddressBech32,
+				StartHeight:    2,
+				AssetDenom:     "nria",
+				AssetPrecision: 18,
+				RateLimit: &AstriaDepositRateLimit{
+					StartHeight:     2,
+					MaxBlockAmount:  big.NewInt(100),
+					OverLimitPolicy: "drop",
+				},
+			},
+			wantErr: fmt.Errorf("invalid rate limit: %w", fmt.Errorf("unknown over limit policy drop")),
+		},
+		{
+			description: "valid config with rate limit",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:  bridgeAddressBech32,
+				StartHeight:    2,
+				AssetDenom:     "nria",
+				AssetPrecision: 18,
+				RateLimit: &AstriaDepositRateLimit{
+					StartHeight:      2,
+					MaxDepositAmount: big.NewInt(10),
+					MaxBlockAmount:   big.NewInt(100),
+					MaxWindowAmount:  big.NewInt(1000),
+					WindowBlocks:     50,
+					OverLimitPolicy:  AstriaDepositOverLimitReject,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "invalid deposit call, erc20 asset",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:  bridgeAddressBech32,
+				SenderAddress:  bridgeAddress,
+				StartHeight:    2,
+				AssetDenom:     "nria",
+				AssetPrecision: 18,
+				Erc20Asset: &AstriaErc20AssetConfig{
+					ContractAddress:   erc20Asset,
+					ContractPrecision: 18,
+				},
+				DepositCall: &AstriaDepositCallConfig{
+					AllowedTargets: []common.Address{erc20Asset},
+					GasLimit:       100000,
+				},
+			},
+			wantErr: fmt.Errorf("deposit calls are only supported for native assets"),
+		},
+		{
+			description: "invalid deposit call, missing sender address",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:  bridgeAddressBech32,
+				StartHeight:    2,
+				AssetDenom:     "nria",
+				AssetPrecision: 18,
+				DepositCall: &AstriaDepositCallConfig{
+					AllowedTargets: []common.Address{erc20Asset},
+					GasLimit:       100000,
+				},
+			},
+			wantErr: fmt.Errorf("sender address must be set to enable deposit calls"),
+		},
+		{
+			description: "invalid deposit call, zero gas limit",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:  bridgeAddressBech32,
+				SenderAddress:  bridgeAddress,
+				StartHeight:    2,
+				AssetDenom:     "nria",
+				AssetPrecision: 18,
+				DepositCall: &AstriaDepositCallConfig{
+					AllowedTargets: []common.Address{erc20Asset},
+				},
+			},
+			wantErr: fmt.Errorf("deposit call gas limit must be greater than 0"),
+		},
+		{
+			description: "invalid deposit call, no allowed targets",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:  bridgeAddressBech32,
+				SenderAddress:  bridgeAddress,
+				StartHeight:    2,
+				AssetDenom:     "nria",
+				AssetPrecision: 18,
+				DepositCall: &AstriaDepositCallConfig{
+					GasLimit: 100000,
+				},
+			},
+			wantErr: fmt.Errorf("deposit call allowed targets must be set"),
+		},
+		{
+			description: "valid config with deposit call",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:  bridgeAddressBech32,
+				SenderAddress:  bridgeAddress,
+				StartHeight:    2,
+				AssetDenom:     "nria",
+				AssetPrecision: 18,
+				DepositCall: &AstriaDepositCallConfig{
+					AllowedTargets: []common.Address{erc20Asset},
+					GasLimit:       100000,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "withdrawer address on erc20 bridge",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:     bridgeAddressBech32,
+				StartHeight:       2,
+				AssetDenom:        "nria",
+				AssetPrecision:    18,
+				Erc20Asset:        &AstriaErc20AssetConfig{ContractAddress: erc20Asset, ContractPrecision: 18},
+				WithdrawerAddress: &erc20Asset,
+			},
+			wantErr: fmt.Errorf("withdrawer address is only supported for native assets"),
+		},
+		{
+			description: "invalid recipient fallback, zero address",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:            bridgeAddressBech32,
+				StartHeight:              2,
+				AssetDenom:               "nria",
+				AssetPrecision:           18,
+				InvalidRecipientFallback: &common.Address{},
+			},
+			wantErr: fmt.Errorf("invalid recipient fallback must not be the zero address"),
+		},
+		{
+			description: "require checksummed recipient without strict recipient height",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:               bridgeAddressBech32,
+				StartHeight:                 2,
+				AssetDenom:                  "nria",
+				AssetPrecision:              18,
+				RequireChecksummedRecipient: true,
+			},
+			wantErr: fmt.Errorf("strict recipient height must be set to validate recipients"),
+		},
+		{
+			description: "invalid recipient fallback without strict recipient height",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:            bridgeAddressBech32,
+				StartHeight:              2,
+				AssetDenom:               "nria",
+				AssetPrecision:           18,
+				InvalidRecipientFallback: &bridgeAddress,
+			},
+			wantErr: fmt.Errorf("strict recipient height must be set to validate recipients"),
+		},
+		{
+			description: "valid config with invalid recipient fallback",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:               bridgeAddressBech32,
+				StartHeight:                 2,
+				AssetDenom:                  "nria",
+				AssetPrecision:              18,
+				StrictRecipientHeight:       5,
+				InvalidRecipientFallback:    &bridgeAddress,
+				RequireChecksummedRecipient: true,
+			},
+			wantErr: nil,
+		},
+		{
+			description: "valid config with strict recipients and without fallback",
+			config: AstriaBridgeAddressConfig{
+				BridgeAddress:         bridgeAddressBech32,
+				StartHeight:           2,
+				AssetDenom:            "nria",
+				AssetPrecision:        18,
+				StrictRecipientHeight: 5,
+			},
+			wantErr: nil,
+		},
 		{
 			description: "valid config",
 			config: AstriaBridgeAddressConfig{
@@ -243,3 +478,304 @@ func TestAstriaBridgeConfigValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestAstriaAuctioneerAddressesAt(t *testing.T) {
+	config := &ChainConfig{
+		AstriaAuctioneerAddresses: map[uint32]string{
+			10: "first",
+			20: "second",
+			22: "third",
+			30: "",
+			40: "fourth",
+		},
+		AstriaAuctioneerOverlapBlocks: 5,
+	}
+
+	tests := []struct {
+		height   uint64
+		expected []string
+	}{
+		{height: 9, expected: []string{}},
+		{height: 10, expected: []string{"first"}},
+		{height: 19, expected: []string{"first"}},
+		{height: 20, expected: []string{"second", "first"}},
+		{height: 22, expected: []string{"third", "second", "first"}},
+		{height: 24, expected: []string{"third", "second", "first"}},
+		{height: 25, expected: []string{"third", "second"}},
+		{height: 27, expected: []string{"third"}},
+		{height: 30, expected: []string{}},
+		{height: 39, expected: []string{}},
+		{height: 40, expected: []string{"fourth"}},
+		{height: 100, expected: []string{"fourth"}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("height %d", test.height), func(t *testing.T) {
+			addresses := config.AstriaAuctioneerAddressesAt(test.height)
+			if !reflect.DeepEqual(addresses, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, addresses)
+			}
+		})
+	}
+
+	config.AstriaAuctioneerOverlapBlocks = 0
+	if addresses := config.AstriaAuctioneerAddressesAt(20); !reflect.DeepEqual(addresses, []string{"second"}) {
+		t.Errorf("expected no overlap, got %v", addresses)
+	}
+}
+
+func TestAstriaFeeCollectorAt(t *testing.T) {
+	first := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	second := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	config := &ChainConfig{
+		AstriaFeeCollectors: map[uint32]common.Address{
+			5:  first,
+			10: second,
+		},
+	}
+
+	tests := []struct {
+		height   uint64
+		expected common.Address
+	}{
+		{height: 4, expected: common.Address{}},
+		{height: 5, expected: first},
+		{height: 9, expected: first},
+		{height: 10, expected: second},
+		{height: 100, expected: second},
+	}
+
+	for _, test := range tests {
+		if collector := config.AstriaFeeCollectorAt(test.height); collector != test.expected {
+			t.Errorf("height %d: expected %v, got %v", test.height, test.expected, collector)
+		}
+	}
+}
+
+func TestAstriaFeeSplitValidation(t *testing.T) {
+	treasury := common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+	zero := common.Address{}
+
+	tests := []struct {
+		description string
+		split       []AstriaFeeShare
+		wantErr     string
+	}{
+		{
+			description: "valid split with burned share",
+			split:       []AstriaFeeShare{{Recipient: &treasury, Bps: 9000}, {Bps: 1000}},
+		},
+		{
+			description: "empty split disabling splitting",
+			split:       []AstriaFeeShare{},
+		},
+		{
+			description: "shares not adding up",
+			split:       []AstriaFeeShare{{Recipient: &treasury, Bps: 9000}},
+			wantErr:     "fee split at height 1 must add up to 10000 bps, got 9000",
+		},
+		{
+			description: "zero share",
+			split:       []AstriaFeeShare{{Recipient: &treasury, Bps: 10000}, {Bps: 0}},
+			wantErr:     "fee split at height 1 has a share of 0 bps",
+		},
+		{
+			description: "zero address recipient",
+			split:       []AstriaFeeShare{{Recipient: &zero, Bps: 10000}},
+			wantErr:     "fee split at height 1 has the zero address as recipient, omit the recipient to burn a share",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			config := &ChainConfig{AstriaFeeSplits: map[uint32][]AstriaFeeShare{1: test.split}}
+			err := config.ValidateAstriaFeeSplits()
+			if test.wantErr == "" && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			if test.wantErr != "" && (err == nil || err.Error() != test.wantErr) {
+				t.Errorf("expected error %q, got %v", test.wantErr, err)
+			}
+			// invalid fee splits are rejected along with the fork order of the chain config
+			err = config.CheckConfigForkOrder()
+			if test.wantErr == "" && err != nil {
+				t.Errorf("unexpected config error %v", err)
+			}
+			if test.wantErr != "" && (err == nil || err.Error() != "invalid fee split config: "+test.wantErr) {
+				t.Errorf("expected config error %q, got %v", test.wantErr, err)
+			}
+		})
+	}
+
+	config := &ChainConfig{AstriaFeeSplits: map[uint32][]AstriaFeeShare{
+		5:  {{Recipient: &treasury, Bps: 10000}},
+		10: {},
+	}}
+	if split := config.AstriaFeeSplitAt(4); len(split) != 0 {
+		t.Errorf("expected no split before activation, got %v", split)
+	}
+	if split := config.AstriaFeeSplitAt(9); len(split) != 1 {
+		t.Errorf("expected split after activation, got %v", split)
+	}
+	if split := config.AstriaFeeSplitAt(10); len(split) != 0 {
+		t.Errorf("expected no split once disabled, got %v", split)
+	}
+}
+
+func TestAstriaBaseFeeVault(t *testing.T) {
+	jsonBuf := []byte(`{
+		"1":{ "minBaseFee": 0, "elasticityMultiplier": 2, "baseFeeChangeDenominator": 8 },
+		"10":{ "minBaseFee": 0, "elasticityMultiplier": 2, "baseFeeChangeDenominator": 8, "baseFeeVault": "0x000000000000000000000000000000000000fee5" }
+	}`)
+
+	var eip1559Params AstriaEIP1559Params
+	if err := json.Unmarshal(jsonBuf, &eip1559Params); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	config := &ChainConfig{AstriaEIP1559Params: &eip1559Params}
+
+	if vault := config.AstriaBaseFeeVault(9); vault != nil {
+		t.Errorf("expected no base fee vault before activation, got %v", vault)
+	}
+	expected := common.HexToAddress("0x000000000000000000000000000000000000fee5")
+	if vault := config.AstriaBaseFeeVault(10); vault == nil || *vault != expected {
+		t.Errorf("expected base fee vault %v, got %v", expected, vault)
+	}
+	if vault := (&ChainConfig{}).AstriaBaseFeeVault(10); vault != nil {
+		t.Errorf("expected no base fee vault without eip1559 params, got %v", vault)
+	}
+}
+
+func TestAstriaFeeBounds(t *testing.T) {
+	jsonBuf := []byte(`{
+		"1":{ "minBaseFee": 0, "elasticityMultiplier": 2, "baseFeeChangeDenominator": 8 },
+		"10":{ "minBaseFee": 0, "elasticityMultiplier": 2, "baseFeeChangeDenominator": 8, "maxBaseFee": 50000000000, "minPriorityFee": 1000000000 }
+	}`)
+
+	var eip1559Params AstriaEIP1559Params
+	if err := json.Unmarshal(jsonBuf, &eip1559Params); err != nil {
+		t.Fatalf("unexpected err %v", err)
+	}
+	config := &ChainConfig{AstriaEIP1559Params: &eip1559Params}
+
+	if maxBaseFee := config.AstriaMaxBaseFee(9); maxBaseFee != nil {
+		t.Errorf("expected no max base fee before activation, got %v", maxBaseFee)
+	}
+	if minTip := config.AstriaMinPriorityFee(9); minTip.Sign() != 0 {
+		t.Errorf("expected no min priority fee before activation, got %v", minTip)
+	}
+	if maxBaseFee := config.AstriaMaxBaseFee(10); maxBaseFee == nil || maxBaseFee.Cmp(big.NewInt(50000000000)) != 0 {
+		t.Errorf("expected max base fee 50000000000, got %v", maxBaseFee)
+	}
+	if minTip := config.AstriaMinPriorityFee(10); minTip.Cmp(big.NewInt(1000000000)) != 0 {
+		t.Errorf("expected min priority fee 1000000000, got %v", minTip)
+	}
+	if maxBaseFee := (&ChainConfig{}).AstriaMaxBaseFee(10); maxBaseFee != nil {
+		t.Errorf("expected no max base fee without eip1559 params, got %v", maxBaseFee)
+	}
+	if minTip := (&ChainConfig{}).AstriaMinPriorityFee(10); minTip.Sign() != 0 {
+		t.Errorf("expected no min priority fee without eip1559 params, got %v", minTip)
+	}
+}
+
+func TestAstriaEIP1559ParamsValidate(t *testing.T) {
+	vault := common.Address{}
+	eip1559Params := NewAstriaEIP1559Params(map[uint64]AstriaEIP1559Param{
+		1:  {MinBaseFee: 10, ElasticityMultiplier: 2, BaseFeeChangeDenominator: 8},
+		5:  {MinBaseFee: 10, ElasticityMultiplier: 0, BaseFeeChangeDenominator: 8},
+		10: {MinBaseFee: 10, MaxBaseFee: 5, ElasticityMultiplier: 2, BaseFeeChangeDenominator: 8},
+		15: {MinBaseFee: 10, ElasticityMultiplier: 2, BaseFeeChangeDenominator: 8, GasLimit: 1},
+		20: {MinBaseFee: 10, ElasticityMultiplier: 2, BaseFeeChangeDenominator: 8, BaseFeeVault: &vault},
+		25: {MinBaseFee: 10, ElasticityMultiplier: 2, BaseFeeChangeDenominator: 8, DepositGasLimit: 100000},
+	})
+	if heights := eip1559Params.Heights(); !reflect.DeepEqual(heights, []uint64{1, 5, 10, 15, 20, 25}) {
+		t.Errorf("unexpected heights %v", heights)
+	}
+	err := eip1559Params.Validate()
+	if err == nil {
+		t.Fatal("expected invalid params")
+	}
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	if len(errs) != 5 {
+		t.Fatalf("expected 5 invalid heights, got %d: %v", len(errs), err)
+	}
+	for i, h := range []uint64{5, 10, 15, 20, 25} {
+		if want := fmt.Sprintf("eip1559 params at height %d:", h); !strings.HasPrefix(errs[i].Error(), want) {
+			t.Errorf("error %d: expected prefix %q, got %q", i, want, errs[i])
+		}
+	}
+}
+
+func TestAstriaDepositGasLimit(t *testing.T) {
+	config := &ChainConfig{
+		AstriaEIP1559Params: NewAstriaEIP1559Params(map[uint64]AstriaEIP1559Param{
+			1:  {MinBaseFee: 10, ElasticityMultiplier: 2, BaseFeeChangeDenominator: 8, GasLimit: 30000000},
+			10: {MinBaseFee: 10, ElasticityMultiplier: 2, BaseFeeChangeDenominator: 8, GasLimit: 30000000, DepositGasLimit: 2000000},
+		}),
+	}
+	if err := config.AstriaEIP1559Params.Validate(); err != nil {
+		t.Fatalf("unexpected invalid params: %v", err)
+	}
+	if gasLimit, ok := config.AstriaGasLimit(9); !ok || gasLimit != 30000000 {
+		t.Errorf("expected gas limit 30000000 before the reservation, got %d", gasLimit)
+	}
+	if depositGasLimit := config.AstriaDepositGasLimit(9); depositGasLimit != 0 {
+		t.Errorf("expected no deposit gas limit before the reservation, got %d", depositGasLimit)
+	}
+	if gasLimit, ok := config.AstriaGasLimit(10); !ok || gasLimit != 32000000 {
+		t.Errorf("expected gas limit 32000000 including the reservation, got %d", gasLimit)
+	}
+	if depositGasLimit := config.AstriaDepositGasLimit(10); depositGasLimit != 2000000 {
+		t.Errorf("expected deposit gas limit 2000000, got %d", depositGasLimit)
+	}
+}
+
+func TestAstriaDepositBridge(t *testing.T) {
+	var (
+		sender = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		erc20  = common.HexToAddress("0x00000000000000000000000000000000000e2c20")
+		other  = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	)
+	config := &ChainConfig{
+		AstriaBridgeAddressConfigs: []AstriaBridgeAddressConfig{
+			{BridgeAddress: "native", StartHeight: 1, AssetDenom: "nria", AssetPrecision: 9},
+			{
+				BridgeAddress:  "erc20",
+				StartHeight:    10,
+				AssetDenom:     "usdc",
+				AssetPrecision: 6,
+				SenderAddress:  sender,
+				Erc20Asset:     &AstriaErc20AssetConfig{ContractAddress: erc20, ContractPrecision: 18},
+			},
+		},
+	}
+	tests := []struct {
+		height uint64
+		from   common.Address
+		to     *common.Address
+		want   string
+	}{
+		{height: 1, from: common.Address{}, to: &other, want: "native"},
+		{height: 0, from: common.Address{}, to: &other, want: ""},
+		{height: 10, from: sender, to: &erc20, want: "erc20"},
+		{height: 9, from: sender, to: &erc20, want: "native"},
+		{height: 10, from: other, to: &erc20, want: "native"},
+		{height: 9, from: other, to: &other, want: "native"},
+	}
+	for i, tt := range tests {
+		cfg := config.AstriaDepositBridge(tt.height, tt.from, tt.to)
+		var got string
+		if cfg != nil {
+			got = cfg.BridgeAddress
+		}
+		if got != tt.want {
+			t.Errorf("test %d: expected bridge %q, got %q", i, tt.want, got)
+		}
+	}
+
+	erc20Bridge := &config.AstriaBridgeAddressConfigs[1]
+	scaled := erc20Bridge.ScaledDepositAmount(big.NewInt(1234))
+	if raw := erc20Bridge.UnscaledDepositAmount(scaled); raw.Cmp(big.NewInt(1234)) != 0 {
+		t.Errorf("expected unscaled amount 1234, got %v", raw)
+	}
+}
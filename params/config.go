@@ -18,6 +18,7 @@ package params
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"sort"
@@ -389,6 +390,131 @@ type ChainConfig struct {
 	AstriaFeeCollectors            map[uint32]common.Address   `json:"astriaFeeCollectors"`
 	AstriaEIP1559Params            *AstriaEIP1559Params        `json:"astriaEIP1559Params,omitempty"`
 	AstriaAuctioneerAddresses      map[uint32]string           `json:"astriaAuctioneerAddresses,omitempty"`
+
+	// AstriaAuctioneerOverlapBlocks is the number of blocks for which the previous
+	// auctioneer address stays valid after a new one is activated in
+	// AstriaAuctioneerAddresses. An empty address in AstriaAuctioneerAddresses disables
+	// auctions from that height on, without any overlap.
+	AstriaAuctioneerOverlapBlocks uint32 `json:"astriaAuctioneerOverlapBlocks,omitempty"`
+
+	// AstriaFeeSplits maps rollup heights to the split of the fees collected by the fee
+	// recipient of the blocks from that height on. The fees are distributed at the end of
+	// each block. An empty split at a height disables splitting from that height on.
+	AstriaFeeSplits map[uint32][]AstriaFeeShare `json:"astriaFeeSplits,omitempty"`
+
+	// AstriaRollupDataEnvelopeHeight is the rollup height from which sequenced data is
+	// decoded according to its envelope prefix, instead of by trial unmarshalling. Sequenced
+	// data is never decoded by its envelope if it is unset.
+	AstriaRollupDataEnvelopeHeight uint32 `json:"astriaRollupDataEnvelopeHeight,omitempty"`
+	// AstriaRollupDataDecoders maps the name of additional rollup data decoders to the
+	// rollup height from which they are enabled. Decoders are never enabled before
+	// AstriaRollupDataEnvelopeHeight.
+	AstriaRollupDataDecoders map[string]uint32 `json:"astriaRollupDataDecoders,omitempty"`
+
+	// AstriaNonceReorderingHeight is the rollup height from which the transactions of
+	// each sender are sorted by nonce within a block before execution, so that a sender
+	// whose transactions were sequenced out of order does not lose them to a nonce gap.
+	// Every sender keeps the positions its transactions were sequenced at.
+	AstriaNonceReorderingHeight *big.Int `json:"astriaNonceReorderingHeight,omitempty"`
+}
+
+// IsAstriaRollupDataEnvelope returns whether sequenced data at the given rollup height
+// is decoded according to its envelope prefix.
+func (c *ChainConfig) IsAstriaRollupDataEnvelope(height uint64) bool {
+	return c.AstriaRollupDataEnvelopeHeight != 0 && uint64(c.AstriaRollupDataEnvelopeHeight) <= height
+}
+
+// IsAstriaNonceReordering returns whether the transactions of each sender are sorted by
+// nonce within the block at the given rollup height.
+func (c *ChainConfig) IsAstriaNonceReordering(num *big.Int) bool {
+	return isBlockForked(c.AstriaNonceReorderingHeight, num)
+}
+
+// IsAstriaRollupDataDecoderEnabled returns whether the named additional rollup data
+// decoder is enabled at the given rollup height.
+func (c *ChainConfig) IsAstriaRollupDataDecoderEnabled(name string, height uint64) bool {
+	if !c.IsAstriaRollupDataEnvelope(height) {
+		return false
+	}
+	activation, ok := c.AstriaRollupDataDecoders[name]
+	return ok && uint64(activation) <= height
+}
+
+// AstriaFeeCollectorAt returns the fee collector of the block at the given rollup height,
+// which is the one of AstriaFeeCollectors with the highest activation height not after it.
+// It is the zero address if no fee collector is active at that height.
+func (c *ChainConfig) AstriaFeeCollectorAt(height uint64) common.Address {
+	collector, activation, found := common.Address{}, uint64(0), false
+	for h, address := range c.AstriaFeeCollectors {
+		if uint64(h) <= height && (!found || uint64(h) > activation) {
+			collector, activation, found = address, uint64(h), true
+		}
+	}
+	return collector
+}
+
+// AstriaFeeSplitAt returns the fee split of the block at the given rollup height, which is
+// the one of AstriaFeeSplits with the highest activation height not after it. It is empty
+// if fees are not split at that height.
+func (c *ChainConfig) AstriaFeeSplitAt(height uint64) []AstriaFeeShare {
+	var split []AstriaFeeShare
+	activation, found := uint64(0), false
+	for h, shares := range c.AstriaFeeSplits {
+		if uint64(h) <= height && (!found || uint64(h) > activation) {
+			split, activation, found = shares, uint64(h), true
+		}
+	}
+	return split
+}
+
+// ValidateAstriaFeeSplits checks that the shares of every fee split add up to the whole fees.
+func (c *ChainConfig) ValidateAstriaFeeSplits() error {
+	for height, shares := range c.AstriaFeeSplits {
+		if len(shares) == 0 {
+			continue
+		}
+		total := uint64(0)
+		for _, share := range shares {
+			if share.Bps == 0 {
+				return fmt.Errorf("fee split at height %d has a share of 0 bps", height)
+			}
+			if share.Recipient != nil && *share.Recipient == (common.Address{}) {
+				return fmt.Errorf("fee split at height %d has the zero address as recipient, omit the recipient to burn a share", height)
+			}
+			total += share.Bps
+		}
+		if total != AstriaFeeSplitTotalBps {
+			return fmt.Errorf("fee split at height %d must add up to %d bps, got %d", height, AstriaFeeSplitTotalBps, total)
+		}
+	}
+	return nil
+}
+
+// AstriaAuctioneerAddressesAt returns the auctioneer addresses whose allocations are
+// valid at the given rollup height, starting with the most recently activated one.
+// It is empty if auctions are not enabled at that height.
+func (c *ChainConfig) AstriaAuctioneerAddressesAt(height uint64) []string {
+	activations := make([]uint64, 0, len(c.AstriaAuctioneerAddresses))
+	for h := range c.AstriaAuctioneerAddresses {
+		if uint64(h) <= height {
+			activations = append(activations, uint64(h))
+		}
+	}
+	sort.Slice(activations, func(i, j int) bool { return activations[i] > activations[j] })
+
+	addresses := []string{}
+	for i, activation := range activations {
+		address := c.AstriaAuctioneerAddresses[uint32(activation)]
+		if address == "" {
+			break
+		}
+		// an address replaced by a later one is only valid within the overlap window
+		if i > 0 && height >= activations[i-1]+uint64(c.AstriaAuctioneerOverlapBlocks) {
+			break
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses
 }
 
 func (c *ChainConfig) AstriaExtraData() []byte {
@@ -414,6 +540,23 @@ type AstriaEIP1559Param struct {
 	MinBaseFee               uint64 `json:"minBaseFee"`
 	ElasticityMultiplier     uint64 `json:"elasticityMultiplier"`
 	BaseFeeChangeDenominator uint64 `json:"baseFeeChangeDenominator"`
+	// GasLimit is the gas limit of every block. If it is not set, the gas limit moves
+	// towards the gas ceiling of the block builder within the bounds of each block.
+	GasLimit uint64 `json:"gasLimit,omitempty"`
+	// DepositGasLimit is gas reserved in every block for deposit transactions, on top
+	// of GasLimit, so that deposits do not crowd out user transactions. Deposits are
+	// executed first, and only the gas they use beyond the reservation is taken from
+	// GasLimit. Requires GasLimit to be set.
+	DepositGasLimit uint64 `json:"depositGasLimit,omitempty"`
+	// BaseFeeVault is credited with the base fee of transactions instead of the fee
+	// recipient of the block, or the base fee being burned if there is none.
+	BaseFeeVault *common.Address `json:"baseFeeVault,omitempty"`
+	// MaxBaseFee caps the base fee of every block. If it is not set, the base fee is
+	// not capped.
+	MaxBaseFee uint64 `json:"maxBaseFee,omitempty"`
+	// MinPriorityFee is the lowest effective priority fee a transaction may pay to be
+	// included in a block.
+	MinPriorityFee uint64 `json:"minPriorityFee,omitempty"`
 }
 
 type AstriaEIP1559Params struct {
@@ -461,6 +604,99 @@ func (c *AstriaEIP1559Params) BaseFeeChangeDenominatorAt(height uint64) uint64 {
 	return DefaultBaseFeeChangeDenominator
 }
 
+func (c *AstriaEIP1559Params) GasLimitAt(height uint64) uint64 {
+	for _, h := range c.orderedHeights {
+		if height >= h {
+			return c.heights[h].GasLimit
+		}
+	}
+	return 0
+}
+
+func (c *AstriaEIP1559Params) DepositGasLimitAt(height uint64) uint64 {
+	for _, h := range c.orderedHeights {
+		if height >= h {
+			return c.heights[h].DepositGasLimit
+		}
+	}
+	return 0
+}
+
+func (c *AstriaEIP1559Params) BaseFeeVaultAt(height uint64) *common.Address {
+	for _, h := range c.orderedHeights {
+		if height >= h {
+			return c.heights[h].BaseFeeVault
+		}
+	}
+	return nil
+}
+
+func (c *AstriaEIP1559Params) MaxBaseFeeAt(height uint64) uint64 {
+	for _, h := range c.orderedHeights {
+		if height >= h {
+			return c.heights[h].MaxBaseFee
+		}
+	}
+	return 0
+}
+
+func (c *AstriaEIP1559Params) MinPriorityFeeAt(height uint64) uint64 {
+	for _, h := range c.orderedHeights {
+		if height >= h {
+			return c.heights[h].MinPriorityFee
+		}
+	}
+	return 0
+}
+
+// Heights returns the activation heights of the scheduled parameters, in ascending order.
+func (c *AstriaEIP1559Params) Heights() []uint64 {
+	heights := make([]uint64, len(c.orderedHeights))
+	for i, h := range c.orderedHeights {
+		heights[len(heights)-1-i] = h
+	}
+	return heights
+}
+
+// Validate checks the parameters scheduled at every height, joining the errors of all
+// the invalid ones.
+func (c *AstriaEIP1559Params) Validate() error {
+	var errs []error
+	for _, h := range c.Heights() {
+		if err := c.heights[h].validate(); err != nil {
+			errs = append(errs, fmt.Errorf("eip1559 params at height %d: %w", h, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p AstriaEIP1559Param) validate() error {
+	if p.ElasticityMultiplier == 0 {
+		return fmt.Errorf("elasticity multiplier must be greater than 0")
+	}
+	if p.BaseFeeChangeDenominator == 0 {
+		return fmt.Errorf("base fee change denominator must be greater than 0")
+	}
+	if p.MaxBaseFee != 0 && p.MaxBaseFee < p.MinBaseFee {
+		return fmt.Errorf("max base fee %d must not be less than min base fee %d", p.MaxBaseFee, p.MinBaseFee)
+	}
+	if p.GasLimit != 0 && (p.GasLimit < MinGasLimit || p.GasLimit > MaxGasLimit) {
+		return fmt.Errorf("gas limit %d must be between %d and %d", p.GasLimit, MinGasLimit, MaxGasLimit)
+	}
+	if p.DepositGasLimit != 0 {
+		if p.GasLimit == 0 {
+			return fmt.Errorf("deposit gas limit requires a gas limit")
+		}
+		if p.GasLimit+p.DepositGasLimit > MaxGasLimit {
+			return fmt.Errorf("gas limit %d and deposit gas limit %d must not exceed %d", p.GasLimit, p.DepositGasLimit, MaxGasLimit)
+		}
+	}
+	if p.BaseFeeVault != nil && *p.BaseFeeVault == (common.Address{}) {
+		return fmt.Errorf("base fee vault must not be the zero address")
+	}
+	return nil
+}
+
 func (c AstriaEIP1559Params) MarshalJSON() ([]byte, error) {
 	return json.Marshal(c.heights)
 }
@@ -775,6 +1011,10 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 			lastFork = cur
 		}
 	}
+	// The fee splits are applied by consensus, so an invalid one must not start a chain
+	if err := c.ValidateAstriaFeeSplits(); err != nil {
+		return fmt.Errorf("invalid fee split config: %w", err)
+	}
 	return nil
 }
 
@@ -865,6 +1105,58 @@ func (c *ChainConfig) ElasticityMultiplier(height uint64) uint64 {
 	return DefaultElasticityMultiplier
 }
 
+// AstriaGasLimit returns the gas limit scheduled for the block at the given height, if any.
+// It includes the gas reserved for deposit transactions.
+func (c *ChainConfig) AstriaGasLimit(height uint64) (uint64, bool) {
+	if c.AstriaEIP1559Params == nil {
+		return 0, false
+	}
+	gasLimit := c.AstriaEIP1559Params.GasLimitAt(height)
+	if gasLimit == 0 {
+		return 0, false
+	}
+	return gasLimit + c.AstriaEIP1559Params.DepositGasLimitAt(height), true
+}
+
+// AstriaDepositGasLimit returns the gas reserved for deposit transactions in the block at
+// the given height. Deposits share the gas limit with user transactions if it is zero.
+func (c *ChainConfig) AstriaDepositGasLimit(height uint64) uint64 {
+	if c.AstriaEIP1559Params == nil || c.AstriaEIP1559Params.GasLimitAt(height) == 0 {
+		return 0
+	}
+	return c.AstriaEIP1559Params.DepositGasLimitAt(height)
+}
+
+// AstriaBaseFeeVault returns the address credited with the base fee of the transactions
+// of the block at the given height, or nil if the base fee is not redirected.
+func (c *ChainConfig) AstriaBaseFeeVault(height uint64) *common.Address {
+	if c.AstriaEIP1559Params != nil {
+		return c.AstriaEIP1559Params.BaseFeeVaultAt(height)
+	}
+	return nil
+}
+
+// AstriaMaxBaseFee returns the cap on the base fee of the block at the given height, or
+// nil if the base fee is not capped.
+func (c *ChainConfig) AstriaMaxBaseFee(height uint64) *big.Int {
+	if c.AstriaEIP1559Params == nil {
+		return nil
+	}
+	if maxBaseFee := c.AstriaEIP1559Params.MaxBaseFeeAt(height); maxBaseFee != 0 {
+		return new(big.Int).SetUint64(maxBaseFee)
+	}
+	return nil
+}
+
+// AstriaMinPriorityFee returns the lowest effective priority fee a transaction must pay
+// to be included in the block at the given height.
+func (c *ChainConfig) AstriaMinPriorityFee(height uint64) *big.Int {
+	if c.AstriaEIP1559Params != nil {
+		return new(big.Int).SetUint64(c.AstriaEIP1559Params.MinPriorityFeeAt(height))
+	}
+	return new(big.Int)
+}
+
 // LatestFork returns the latest time-based fork that would be active for the given time.
 func (c *ChainConfig) LatestFork(time uint64) forks.Fork {
 	// Assume last non-time-based fork has passed.
@@ -1053,18 +1345,173 @@ func (c *ChainConfig) Rules(num *big.Int, isMerge bool, timestamp uint64) Rules
 	}
 }
 
+// AstriaFeeSplitTotalBps is the sum of the shares of a fee split, in basis points.
+const AstriaFeeSplitTotalBps = 10000
+
+// AstriaFeeShare is the share of a fee split paid to a recipient.
+type AstriaFeeShare struct {
+	// Recipient of the share. The share is burned if no recipient is set.
+	Recipient *common.Address `json:"recipient,omitempty"`
+	// Bps is the share of the fees in basis points.
+	Bps uint64 `json:"bps"`
+}
+
 type AstriaBridgeAddressConfig struct {
-	BridgeAddress  string                  `json:"bridgeAddress"`
-	SenderAddress  common.Address          `json:"senderAddress,omitempty"`
-	StartHeight    uint32                  `json:"startHeight"`
-	AssetDenom     string                  `json:"assetDenom"`
-	AssetPrecision uint16                  `json:"assetPrecision"`
-	Erc20Asset     *AstriaErc20AssetConfig `json:"erc20Asset,omitempty"`
+	BridgeAddress  string                   `json:"bridgeAddress"`
+	SenderAddress  common.Address           `json:"senderAddress,omitempty"`
+	StartHeight    uint32                   `json:"startHeight"`
+	AssetDenom     string                   `json:"assetDenom"`
+	AssetPrecision uint16                   `json:"assetPrecision"`
+	Erc20Asset     *AstriaErc20AssetConfig  `json:"erc20Asset,omitempty"`
+	RateLimit      *AstriaDepositRateLimit  `json:"rateLimit,omitempty"`
+	DepositCall    *AstriaDepositCallConfig `json:"depositCall,omitempty"`
+	// StrictRecipientHeight is the rollup height from which the destination chain address of
+	// deposits is strictly validated. Deposits whose recipient is not a well formed hex address,
+	// or fails its EIP-55 checksum, are minted to InvalidRecipientFallback, or rejected if it is
+	// unset. Before this height, the destination chain address is parsed leniently and
+	// malformed addresses are truncated or zero padded.
+	StrictRecipientHeight uint64 `json:"strictRecipientHeight,omitempty"`
+	// InvalidRecipientFallback is the address deposits with an invalid recipient are minted to.
+	InvalidRecipientFallback *common.Address `json:"invalidRecipientFallback,omitempty"`
+	// RequireChecksummedRecipient additionally treats recipients which are not EIP-55
+	// checksummed as invalid.
+	RequireChecksummedRecipient bool `json:"requireChecksummedRecipient,omitempty"`
+	// WithdrawerAddress is the contract emitting the withdrawal events of a native asset
+	// bridge. Withdrawals of native assets are only attributed to the bridge if it is set.
+	WithdrawerAddress *common.Address `json:"withdrawerAddress,omitempty"`
+}
+
+// AstriaDepositCallConfig enables deposits which call a rollup contract. Such deposits
+// carry `<recipient>:<target>:<0x prefixed calldata>` as destination chain address. The
+// deposited amount is minted to an address derived from the bridge sender address and
+// the source of the deposit, which then calls the target with the amount as value. If
+// the target is not allowed or the calldata is empty, the deposit is minted to the
+// recipient as usual. If the call fails, the amount is transferred to the recipient.
+// Only native asset bridges support deposit calls.
+type AstriaDepositCallConfig struct {
+	AllowedTargets []common.Address `json:"allowedTargets"`
+	GasLimit       uint64           `json:"gasLimit"`
+}
+
+// IsAllowedTarget returns whether deposits can call the given contract.
+func (c *AstriaDepositCallConfig) IsAllowedTarget(target common.Address) bool {
+	for _, allowed := range c.AllowedTargets {
+		if allowed == target {
+			return true
+		}
+	}
+	return false
 }
 
 type AstriaErc20AssetConfig struct {
 	ContractAddress   common.Address `json:"contractAddress"`
 	ContractPrecision uint16         `json:"contractPrecision"`
+	// MintGasLimit is the gas available to the `mint` call of a deposit. Defaults to
+	// DefaultAstriaErc20MintGasLimit.
+	MintGasLimit uint64 `json:"mintGasLimit,omitempty"`
+	// FailedMintRecipient receives the minted tokens when the `mint` call to the deposit
+	// recipient fails. The fallback mint is given a fresh MintGasLimit budget. If unset,
+	// deposits whose mint fails are lost.
+	FailedMintRecipient *common.Address `json:"failedMintRecipient,omitempty"`
+}
+
+// DefaultAstriaErc20MintGasLimit is the gas limit of ERC20 deposit mints when the bridge
+// does not configure one. Mints cost ~14k gas, however this can vary based on existing
+// storage, so a buffer is added.
+const DefaultAstriaErc20MintGasLimit = 64000
+
+// MintGas returns the gas limit of the `mint` call of a deposit.
+func (c *AstriaErc20AssetConfig) MintGas() uint64 {
+	if c.MintGasLimit == 0 {
+		return DefaultAstriaErc20MintGasLimit
+	}
+	return c.MintGasLimit
+}
+
+// AstriaDepositOverLimitPolicy determines what happens to a deposit which would
+// exceed one of the per block or rolling window caps of its bridge.
+type AstriaDepositOverLimitPolicy string
+
+const (
+	// AstriaDepositOverLimitDefer keeps over limit deposits in a queue and mints
+	// them in subsequent blocks, in order, as soon as the caps allow it.
+	AstriaDepositOverLimitDefer AstriaDepositOverLimitPolicy = "defer"
+	// AstriaDepositOverLimitReject drops over limit deposits.
+	AstriaDepositOverLimitReject AstriaDepositOverLimitPolicy = "reject"
+)
+
+// AstriaDepositRateLimit caps the amount a bridge can mint on the rollup. All amounts
+// are denominated in the sequencer asset, ie. before scaling to the rollup precision.
+// A nil amount disables the corresponding cap.
+type AstriaDepositRateLimit struct {
+	// StartHeight is the first block the rate limit applies to.
+	StartHeight uint64 `json:"startHeight"`
+	// MaxDepositAmount is the largest amount a single deposit can carry. Deposits above
+	// it are always rejected, as deferring them would never let them through.
+	MaxDepositAmount *big.Int `json:"maxDepositAmount,omitempty"`
+	// MaxBlockAmount is the total amount which can be minted in a single block.
+	MaxBlockAmount *big.Int `json:"maxBlockAmount,omitempty"`
+	// MaxWindowAmount is the total amount which can be minted over the last
+	// WindowBlocks blocks, including the block being built.
+	MaxWindowAmount *big.Int `json:"maxWindowAmount,omitempty"`
+	WindowBlocks    uint64   `json:"windowBlocks,omitempty"`
+	// OverLimitPolicy defaults to AstriaDepositOverLimitDefer.
+	OverLimitPolicy AstriaDepositOverLimitPolicy `json:"overLimitPolicy,omitempty"`
+	// MaxDeferredDeposits bounds the deferred deposits queue, deposits which do not
+	// fit in the queue are rejected. Defaults to DefaultAstriaMaxDeferredDeposits.
+	MaxDeferredDeposits uint64 `json:"maxDeferredDeposits,omitempty"`
+}
+
+// DefaultAstriaMaxDeferredDeposits is the deferred deposits queue size used when a
+// rate limit does not set one.
+const DefaultAstriaMaxDeferredDeposits = 1024
+
+func (rl *AstriaDepositRateLimit) Validate() error {
+	if rl.StartHeight == 0 {
+		return fmt.Errorf("start height must be greater than 0")
+	}
+	if rl.MaxDepositAmount != nil && rl.MaxDepositAmount.Sign() <= 0 {
+		return fmt.Errorf("max deposit amount must be greater than 0")
+	}
+	if rl.MaxBlockAmount != nil && rl.MaxBlockAmount.Sign() <= 0 {
+		return fmt.Errorf("max block amount must be greater than 0")
+	}
+	if rl.MaxWindowAmount != nil && rl.MaxWindowAmount.Sign() <= 0 {
+		return fmt.Errorf("max window amount must be greater than 0")
+	}
+	if rl.MaxWindowAmount != nil && rl.WindowBlocks == 0 {
+		return fmt.Errorf("window blocks must be set when max window amount is set")
+	}
+	if rl.MaxWindowAmount == nil && rl.WindowBlocks != 0 {
+		return fmt.Errorf("max window amount must be set when window blocks is set")
+	}
+	switch rl.OverLimitPolicy {
+	case "", AstriaDepositOverLimitDefer, AstriaDepositOverLimitReject:
+	default:
+		return fmt.Errorf("unknown over limit policy %s", rl.OverLimitPolicy)
+	}
+	return nil
+}
+
+// ActiveAt returns whether the rate limit applies to the block at the given height.
+func (rl *AstriaDepositRateLimit) ActiveAt(height uint64) bool {
+	return rl != nil && height >= rl.StartHeight
+}
+
+// Policy returns the configured over limit policy, defaulting to deferral.
+func (rl *AstriaDepositRateLimit) Policy() AstriaDepositOverLimitPolicy {
+	if rl.OverLimitPolicy == "" {
+		return AstriaDepositOverLimitDefer
+	}
+	return rl.OverLimitPolicy
+}
+
+// DeferredDepositsLimit returns the maximum number of deposits which can be deferred.
+func (rl *AstriaDepositRateLimit) DeferredDepositsLimit() uint64 {
+	if rl.MaxDeferredDeposits == 0 {
+		return DefaultAstriaMaxDeferredDeposits
+	}
+	return rl.MaxDeferredDeposits
 }
 
 func (abc *AstriaBridgeAddressConfig) Validate(genesisPrefix string) error {
@@ -1094,10 +1541,47 @@ func (abc *AstriaBridgeAddressConfig) Validate(genesisPrefix string) error {
 	if abc.Erc20Asset != nil && abc.AssetPrecision > abc.Erc20Asset.ContractPrecision {
 		return fmt.Errorf("asset precision must be less than or equal to contract precision")
 	}
+	if abc.Erc20Asset != nil && abc.Erc20Asset.FailedMintRecipient != nil && *abc.Erc20Asset.FailedMintRecipient == (common.Address{}) {
+		return fmt.Errorf("failed mint recipient must not be the zero address")
+	}
+	if abc.DepositCall != nil {
+		if abc.Erc20Asset != nil {
+			return fmt.Errorf("deposit calls are only supported for native assets")
+		}
+		if abc.SenderAddress == (common.Address{}) {
+			return fmt.Errorf("sender address must be set to enable deposit calls")
+		}
+		if abc.DepositCall.GasLimit == 0 {
+			return fmt.Errorf("deposit call gas limit must be greater than 0")
+		}
+		if len(abc.DepositCall.AllowedTargets) == 0 {
+			return fmt.Errorf("deposit call allowed targets must be set")
+		}
+	}
+	if abc.WithdrawerAddress != nil && abc.Erc20Asset != nil {
+		return fmt.Errorf("withdrawer address is only supported for native assets")
+	}
+	if abc.InvalidRecipientFallback != nil && *abc.InvalidRecipientFallback == (common.Address{}) {
+		return fmt.Errorf("invalid recipient fallback must not be the zero address")
+	}
+	if (abc.InvalidRecipientFallback != nil || abc.RequireChecksummedRecipient) && abc.StrictRecipientHeight == 0 {
+		return fmt.Errorf("strict recipient height must be set to validate recipients")
+	}
+	if abc.RateLimit != nil {
+		if err := abc.RateLimit.Validate(); err != nil {
+			return fmt.Errorf("invalid rate limit: %w", err)
+		}
+	}
 
 	return nil
 }
 
+// IsStrictRecipient returns whether the recipients of deposits are strictly validated at
+// the given rollup height.
+func (abc *AstriaBridgeAddressConfig) IsStrictRecipient(height uint64) bool {
+	return abc.StrictRecipientHeight != 0 && height >= abc.StrictRecipientHeight
+}
+
 func (abc *AstriaBridgeAddressConfig) ScaledDepositAmount(deposit *big.Int) *big.Int {
 	var exponent uint16
 	if abc.Erc20Asset != nil {
@@ -1109,3 +1593,57 @@ func (abc *AstriaBridgeAddressConfig) ScaledDepositAmount(deposit *big.Int) *big
 
 	return new(big.Int).Mul(deposit, multiplier)
 }
+
+// UnscaledDepositAmount converts an amount credited on the rollup back to the amount
+// deposited on the sequencer, reversing ScaledDepositAmount.
+func (abc *AstriaBridgeAddressConfig) UnscaledDepositAmount(amount *big.Int) *big.Int {
+	var exponent uint16
+	if abc.Erc20Asset != nil {
+		exponent = abc.Erc20Asset.ContractPrecision - abc.AssetPrecision
+	} else {
+		exponent = 18 - abc.AssetPrecision
+	}
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+
+	return new(big.Int).Div(amount, divisor)
+}
+
+// AstriaDepositBridge returns the config of the bridge which created a deposit transaction
+// sent from the given address to the given address at the given height. ERC20 bridges are
+// matched on their sender and contract address, any other deposit is attributed to the native
+// bridge, as deposit calls are sent from an address derived for each deposit.
+// Returns nil if no bridge active at the height matches.
+func (c *ChainConfig) AstriaDepositBridge(height uint64, from common.Address, to *common.Address) *AstriaBridgeAddressConfig {
+	var native *AstriaBridgeAddressConfig
+	for i := range c.AstriaBridgeAddressConfigs {
+		cfg := &c.AstriaBridgeAddressConfigs[i]
+		if height < uint64(cfg.StartHeight) {
+			continue
+		}
+		if cfg.Erc20Asset == nil {
+			native = cfg
+		} else if cfg.SenderAddress == from && to != nil && cfg.Erc20Asset.ContractAddress == *to {
+			return cfg
+		}
+	}
+	return native
+}
+
+// AstriaWithdrawalBridge returns the config of the bridge whose contract emitted a
+// withdrawal event at the given height: the ERC20 contract of an ERC20 bridge, or the
+// withdrawer of a native bridge. Returns nil if no bridge active at the height matches.
+func (c *ChainConfig) AstriaWithdrawalBridge(height uint64, contract common.Address) *AstriaBridgeAddressConfig {
+	for i := range c.AstriaBridgeAddressConfigs {
+		cfg := &c.AstriaBridgeAddressConfigs[i]
+		if height < uint64(cfg.StartHeight) {
+			continue
+		}
+		if cfg.Erc20Asset != nil && cfg.Erc20Asset.ContractAddress == contract {
+			return cfg
+		}
+		if cfg.WithdrawerAddress != nil && *cfg.WithdrawerAddress == contract {
+			return cfg
+		}
+	}
+	return nil
+}
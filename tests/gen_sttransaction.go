@@ -17,19 +17,22 @@ var _ = (*stTransactionMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (s stTransaction) MarshalJSON() ([]byte, error) {
 	type stTransaction struct {
-		GasPrice             *math.HexOrDecimal256 `json:"gasPrice"`
-		MaxFeePerGas         *math.HexOrDecimal256 `json:"maxFeePerGas"`
-		MaxPriorityFeePerGas *math.HexOrDecimal256 `json:"maxPriorityFeePerGas"`
-		Nonce                math.HexOrDecimal64   `json:"nonce"`
-		To                   string                `json:"to"`
-		Data                 []string              `json:"data"`
-		AccessLists          []*types.AccessList   `json:"accessLists,omitempty"`
-		GasLimit             []math.HexOrDecimal64 `json:"gasLimit"`
-		Value                []string              `json:"value"`
-		PrivateKey           hexutil.Bytes         `json:"secretKey"`
-		Sender               *common.Address       `json:"sender"`
-		BlobVersionedHashes  []common.Hash         `json:"blobVersionedHashes,omitempty"`
-		BlobGasFeeCap        *math.HexOrDecimal256 `json:"maxFeePerBlobGas,omitempty"`
+		GasPrice               *math.HexOrDecimal256 `json:"gasPrice"`
+		MaxFeePerGas           *math.HexOrDecimal256 `json:"maxFeePerGas"`
+		MaxPriorityFeePerGas   *math.HexOrDecimal256 `json:"maxPriorityFeePerGas"`
+		Nonce                  math.HexOrDecimal64   `json:"nonce"`
+		To                     string                `json:"to"`
+		Data                   []string              `json:"data"`
+		AccessLists            []*types.AccessList   `json:"accessLists,omitempty"`
+		GasLimit               []math.HexOrDecimal64 `json:"gasLimit"`
+		Value                  []string              `json:"value"`
+		PrivateKey             hexutil.Bytes         `json:"secretKey"`
+		Sender                 *common.Address       `json:"sender"`
+		BlobVersionedHashes    []common.Hash         `json:"blobVersionedHashes,omitempty"`
+		BlobGasFeeCap          *math.HexOrDecimal256 `json:"maxFeePerBlobGas,omitempty"`
+		Deposit                bool                  `json:"deposit,omitempty"`
+		DepositFallbackData    hexutil.Bytes         `json:"depositFallbackData,omitempty"`
+		DepositRefundRecipient *common.Address       `json:"depositRefundRecipient,omitempty"`
 	}
 	var enc stTransaction
 	enc.GasPrice = (*math.HexOrDecimal256)(s.GasPrice)
@@ -50,25 +53,31 @@ func (s stTransaction) MarshalJSON() ([]byte, error) {
 	enc.Sender = s.Sender
 	enc.BlobVersionedHashes = s.BlobVersionedHashes
 	enc.BlobGasFeeCap = (*math.HexOrDecimal256)(s.BlobGasFeeCap)
+	enc.Deposit = s.Deposit
+	enc.DepositFallbackData = s.DepositFallbackData
+	enc.DepositRefundRecipient = s.DepositRefundRecipient
 	return json.Marshal(&enc)
 }
 
 // UnmarshalJSON unmarshals from JSON.
 func (s *stTransaction) UnmarshalJSON(input []byte) error {
 	type stTransaction struct {
-		GasPrice             *math.HexOrDecimal256 `json:"gasPrice"`
-		MaxFeePerGas         *math.HexOrDecimal256 `json:"maxFeePerGas"`
-		MaxPriorityFeePerGas *math.HexOrDecimal256 `json:"maxPriorityFeePerGas"`
-		Nonce                *math.HexOrDecimal64  `json:"nonce"`
-		To                   *string               `json:"to"`
-		Data                 []string              `json:"data"`
-		AccessLists          []*types.AccessList   `json:"accessLists,omitempty"`
-		GasLimit             []math.HexOrDecimal64 `json:"gasLimit"`
-		Value                []string              `json:"value"`
-		PrivateKey           *hexutil.Bytes        `json:"secretKey"`
-		Sender               *common.Address       `json:"sender"`
-		BlobVersionedHashes  []common.Hash         `json:"blobVersionedHashes,omitempty"`
-		BlobGasFeeCap        *math.HexOrDecimal256 `json:"maxFeePerBlobGas,omitempty"`
+		GasPrice               *math.HexOrDecimal256 `json:"gasPrice"`
+		MaxFeePerGas           *math.HexOrDecimal256 `json:"maxFeePerGas"`
+		MaxPriorityFeePerGas   *math.HexOrDecimal256 `json:"maxPriorityFeePerGas"`
+		Nonce                  *math.HexOrDecimal64  `json:"nonce"`
+		To                     *string               `json:"to"`
+		Data                   []string              `json:"data"`
+		AccessLists            []*types.AccessList   `json:"accessLists,omitempty"`
+		GasLimit               []math.HexOrDecimal64 `json:"gasLimit"`
+		Value                  []string              `json:"value"`
+		PrivateKey             *hexutil.Bytes        `json:"secretKey"`
+		Sender                 *common.Address       `json:"sender"`
+		BlobVersionedHashes    []common.Hash         `json:"blobVersionedHashes,omitempty"`
+		BlobGasFeeCap          *math.HexOrDecimal256 `json:"maxFeePerBlobGas,omitempty"`
+		Deposit                *bool                 `json:"deposit,omitempty"`
+		DepositFallbackData    *hexutil.Bytes        `json:"depositFallbackData,omitempty"`
+		DepositRefundRecipient *common.Address       `json:"depositRefundRecipient,omitempty"`
 	}
 	var dec stTransaction
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -116,5 +125,14 @@ func (s *stTransaction) UnmarshalJSON(input []byte) error {
 	if dec.BlobGasFeeCap != nil {
 		s.BlobGasFeeCap = (*big.Int)(dec.BlobGasFeeCap)
 	}
+	if dec.Deposit != nil {
+		s.Deposit = *dec.Deposit
+	}
+	if dec.DepositFallbackData != nil {
+		s.DepositFallbackData = *dec.DepositFallbackData
+	}
+	if dec.DepositRefundRecipient != nil {
+		s.DepositRefundRecipient = dec.DepositRefundRecipient
+	}
 	return nil
 }
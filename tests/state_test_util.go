@@ -123,6 +123,13 @@ type stTransaction struct {
 	Sender               *common.Address     `json:"sender"`
 	BlobVersionedHashes  []common.Hash       `json:"blobVersionedHashes,omitempty"`
 	BlobGasFeeCap        *big.Int            `json:"maxFeePerBlobGas,omitempty"`
+
+	// Deposit marks the transaction as an Astria deposit sent by Sender. Deposits are
+	// not signed, pay no fees and are executed with the deposit semantics of the
+	// state transition.
+	Deposit                bool            `json:"deposit,omitempty"`
+	DepositFallbackData    []byte          `json:"depositFallbackData,omitempty"`
+	DepositRefundRecipient *common.Address `json:"depositRefundRecipient,omitempty"`
 }
 
 type stTransactionMarshaling struct {
@@ -133,6 +140,7 @@ type stTransactionMarshaling struct {
 	GasLimit             []math.HexOrDecimal64
 	PrivateKey           hexutil.Bytes
 	BlobGasFeeCap        *math.HexOrDecimal256
+	DepositFallbackData  hexutil.Bytes
 }
 
 // GetChainConfig takes a fork definition and returns a chain config.
@@ -397,6 +405,30 @@ func (tx *stTransaction) toMessage(ps stPostState, baseFee *big.Int) (*core.Mess
 	if tx.AccessLists != nil && tx.AccessLists[ps.Indexes.Data] != nil {
 		accessList = *tx.AccessLists[ps.Indexes.Data]
 	}
+	if tx.Deposit {
+		if tx.Sender == nil {
+			return nil, errors.New("deposit tx without sender")
+		}
+		if to == nil {
+			return nil, errors.New("deposit tx without recipient")
+		}
+		msg := &core.Message{
+			From:                from,
+			To:                  to,
+			Value:               value,
+			GasLimit:            gasLimit,
+			GasPrice:            new(big.Int),
+			GasFeeCap:           new(big.Int),
+			GasTipCap:           new(big.Int),
+			Data:                data,
+			IsDepositTx:         true,
+			DepositFallbackData: tx.DepositFallbackData,
+		}
+		if tx.DepositRefundRecipient != nil {
+			msg.DepositRefundRecipient = *tx.DepositRefundRecipient
+		}
+		return msg, nil
+	}
 	// If baseFee provided, set gasPrice to effectiveGasPrice.
 	gasPrice := tx.GasPrice
 	if baseFee != nil {